@@ -0,0 +1,115 @@
+package simplelog
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// OverflowPolicy controls what an asynchronous Logger does when its
+// buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the logging call until there is room
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDrop discards the message and counts it in Dropped
+	OverflowDrop
+)
+
+var errDropped = errors.New("async buffer full, message dropped")
+
+type asyncWrite struct {
+	w io.Writer
+	p []byte
+
+	// flushed, when set, marks a flush request and is closed once all
+	// previously queued writes are done
+	flushed chan struct{}
+}
+
+type asyncWriter struct {
+	writeChan chan asyncWrite
+	policy    OverflowPolicy
+	doneChan  chan struct{}
+}
+
+// SetAsync switches the Logger to asynchronous mode, formatted messages are
+// queued in a buffer of bufferSize and written to the output by a background
+// goroutine.  When the buffer is full policy decides whether the logging call
+// blocks or the message is dropped.
+//
+// A bufferSize of 0 restores synchronous writes, after any queued messages
+// have been written.
+func (l *Logger) SetAsync(bufferSize int, policy OverflowPolicy) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.stopAsync()
+	if bufferSize <= 0 {
+		return
+	}
+
+	a := &asyncWriter{
+		writeChan: make(chan asyncWrite, bufferSize),
+		policy:    policy,
+		doneChan:  make(chan struct{}),
+	}
+	go a.run()
+	l.async = a
+}
+
+// Dropped returns the number of messages dropped because the asynchronous
+// buffer was full
+func (l *Logger) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// stopAsync must be called with the Logger's lock held, it waits for all
+// queued messages to be written
+func (l *Logger) stopAsync() {
+	if l.async == nil {
+		return
+	}
+	close(l.async.writeChan)
+	<-l.async.doneChan
+	l.async = nil
+}
+
+// drainAsync must be called with the Logger's lock held, it waits for all
+// queued messages to be written
+func (l *Logger) drainAsync() {
+	if l.async == nil {
+		return
+	}
+	flushed := make(chan struct{})
+	l.async.writeChan <- asyncWrite{flushed: flushed}
+	<-flushed
+}
+
+// enqueue must be called with the Logger's lock held
+func (l *Logger) enqueue(out io.Writer, p []byte) error {
+	w := asyncWrite{w: out, p: p}
+	if l.async.policy == OverflowBlock {
+		l.async.writeChan <- w
+		return nil
+	}
+	select {
+	case l.async.writeChan <- w:
+		return nil
+	default:
+		atomic.AddUint64(&l.dropped, 1)
+		return errDropped
+	}
+}
+
+func (a *asyncWriter) run() {
+	for w := range a.writeChan {
+		if w.flushed != nil {
+			close(w.flushed)
+			continue
+		}
+		w.w.Write(w.p)
+	}
+	close(a.doneChan)
+}
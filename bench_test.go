@@ -0,0 +1,104 @@
+package simplelog
+
+import (
+	"io"
+	"testing"
+)
+
+func newBenchLogger(color ColorMode) *Logger {
+	l := NewLogger(INFO)
+	l.SetOutput(io.Discard)
+	l.SetColor(color)
+	return l
+}
+
+// assertAllocs fails t when f allocates more than max times per run
+func assertAllocs(t *testing.T, name string, max float64, f func()) {
+	t.Helper()
+	if allocs := testing.AllocsPerRun(1000, f); allocs > max {
+		t.Errorf("%s: %v allocations per op, want <= %v", name, allocs, max)
+	}
+}
+
+func TestAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector adds allocations")
+	}
+	l := newBenchLogger(ColorNever)
+	assertAllocs(t, "disabled", 0, func() { l.Debug("hello %d", 1) })
+	// the formatted message is the only allocation
+	assertAllocs(t, "enabled", 1, func() { l.Info("hello world") })
+	assertAllocs(t, "enabled with args", 1, func() { l.Info("hello %s", "world") })
+
+	colored := newBenchLogger(ColorAlways)
+	assertAllocs(t, "colored", 1, func() { colored.Info("hello world") })
+}
+
+func BenchmarkLogEnabled(b *testing.B) {
+	l := newBenchLogger(ColorNever)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("hello %d", i)
+	}
+}
+
+func BenchmarkLogDisabled(b *testing.B) {
+	l := newBenchLogger(ColorNever)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debug("hello %d", i)
+	}
+}
+
+func BenchmarkLogTTY(b *testing.B) {
+	l := newBenchLogger(ColorAlways)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("hello %d", i)
+	}
+}
+
+func BenchmarkLogNonTTY(b *testing.B) {
+	l := newBenchLogger(ColorNever)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("hello world")
+	}
+}
+
+func BenchmarkLogFields(b *testing.B) {
+	l := newBenchLogger(ColorNever).With(Fields{"id": 1, "user": "matt"})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("hello world")
+	}
+}
+
+func BenchmarkLogJSON(b *testing.B) {
+	l := newBenchLogger(ColorNever)
+	l.SetFormat(JSONFormat)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("hello world")
+	}
+}
+
+func BenchmarkLogConcurrent(b *testing.B) {
+	l := newBenchLogger(ColorNever)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Info("hello world")
+		}
+	})
+}
+
+func BenchmarkLogConcurrentLoggers(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		l := newBenchLogger(ColorNever)
+		for pb.Next() {
+			l.Info("hello world")
+		}
+	})
+}
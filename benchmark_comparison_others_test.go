@@ -0,0 +1,83 @@
+//go:build bench_compare
+
+package simplelog
+
+import (
+	"io"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func BenchmarkZerolog(b *testing.B) {
+	l := zerolog.New(io.Discard).Level(zerolog.InfoLevel).With().Timestamp().Logger()
+	fields := make([][2]string, 0, len(comparisonFields))
+	for k, v := range comparisonFields {
+		fields = append(fields, [2]string{k, v.(string)})
+	}
+	logEvent := func(e *zerolog.Event) {
+		for _, f := range fields {
+			e = e.Str(f[0], f[1])
+		}
+		e.Msg(comparisonMsg)
+	}
+
+	b.Run("enabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			logEvent(l.Info())
+		}
+	})
+	b.Run("disabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			logEvent(l.Debug())
+		}
+	})
+}
+
+func BenchmarkZap(b *testing.B) {
+	enc := zapcore.NewConsoleEncoder(zap.NewProductionEncoderConfig())
+	l := zap.New(zapcore.NewCore(enc, zapcore.AddSync(io.Discard), zapcore.InfoLevel))
+	fields := make([]zap.Field, 0, len(comparisonFields))
+	for k, v := range comparisonFields {
+		fields = append(fields, zap.String(k, v.(string)))
+	}
+
+	b.Run("enabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.Info(comparisonMsg, fields...)
+		}
+	})
+	b.Run("disabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.Debug(comparisonMsg, fields...)
+		}
+	})
+}
+
+func BenchmarkLogrus(b *testing.B) {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.SetLevel(logrus.InfoLevel)
+	l.SetFormatter(&logrus.TextFormatter{DisableColors: true})
+	fields := logrus.Fields(comparisonFields)
+
+	b.Run("enabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.WithFields(fields).Info(comparisonMsg)
+		}
+	})
+	b.Run("disabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.WithFields(fields).Debug(comparisonMsg)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package simplelog
+
+import (
+	"io"
+	"testing"
+)
+
+// The comparison benchmarks log the same message with the same 10 string
+// fields to io.Discard, at an enabled and a disabled level.  Benchmarks for
+// other logging packages are only built with the bench_compare tag so they
+// don't add dependencies to normal builds:
+//
+//	go test -tags bench_compare -run '^$' -bench 'Simplelog|Zerolog|Zap|Logrus'
+
+const comparisonMsg = "request handled"
+
+var comparisonFields = Fields{
+	"field0": "value0",
+	"field1": "value1",
+	"field2": "value2",
+	"field3": "value3",
+	"field4": "value4",
+	"field5": "value5",
+	"field6": "value6",
+	"field7": "value7",
+	"field8": "value8",
+	"field9": "value9",
+}
+
+func BenchmarkSimplelog(b *testing.B) {
+	l := NewLogger(INFO)
+	l.SetOutput(io.Discard)
+	l.SetColor(ColorNever)
+
+	b.Run("enabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.With(comparisonFields).Info(comparisonMsg)
+		}
+	})
+	b.Run("disabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l.With(comparisonFields).Debug(comparisonMsg)
+		}
+	})
+}
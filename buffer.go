@@ -0,0 +1,63 @@
+package simplelog
+
+import (
+	"sync"
+	"time"
+)
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+func putBuffer(b *[]byte) {
+	// don't hold on to unusually large buffers
+	if cap(*b) > 64*1024 {
+		return
+	}
+	*b = (*b)[:0]
+	bufferPool.Put(b)
+}
+
+// appendTimestamp appends dt formatted as "2006-01-02 15:04:05.000000"
+func appendTimestamp(b []byte, dt time.Time) []byte {
+	year, month, day := dt.Date()
+	hour, minute, second := dt.Clock()
+	b = appendPadded(b, uint64(year), 4, '0')
+	b = append(b, '-')
+	b = appendPadded(b, uint64(month), 2, '0')
+	b = append(b, '-')
+	b = appendPadded(b, uint64(day), 2, '0')
+	b = append(b, ' ')
+	b = appendPadded(b, uint64(hour), 2, '0')
+	b = append(b, ':')
+	b = appendPadded(b, uint64(minute), 2, '0')
+	b = append(b, ':')
+	b = appendPadded(b, uint64(second), 2, '0')
+	b = append(b, '.')
+	return appendPadded(b, uint64(dt.Nanosecond()/1e3), 6, '0')
+}
+
+// appendPadded appends n in decimal, left padded with pad to width
+func appendPadded(b []byte, n uint64, width int, pad byte) []byte {
+	var digits [20]byte
+	i := len(digits)
+	for {
+		i--
+		digits[i] = byte('0' + n%10)
+		n /= 10
+		if n == 0 {
+			break
+		}
+	}
+	for w := len(digits) - i; w < width; w++ {
+		b = append(b, pad)
+	}
+	return append(b, digits[i:]...)
+}
@@ -0,0 +1,69 @@
+package simplelog
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+var thisPackage string
+
+func init() {
+	pc, _, _, _ := runtime.Caller(0)
+	thisPackage = packageOf(runtime.FuncForPC(pc).Name())
+}
+
+// SetReportCaller toggles including the file name and line number of the
+// log call in each message
+func (l *Logger) SetReportCaller(enabled bool) {
+	l.Lock()
+	l.reportCaller = enabled
+	l.Unlock()
+}
+
+// SetReportCaller toggles caller reporting on the default (global) logger
+func SetReportCaller(enabled bool) {
+	defaultLogger.Load().SetReportCaller(enabled)
+}
+
+// callerLocation returns the "file.go:line" of the first caller outside of
+// this package
+func callerLocation() string {
+	frame, ok := externalCaller()
+	if !ok {
+		return ""
+	}
+	return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+}
+
+// externalCaller returns the first stack frame outside of this package,
+// skipping the package level convenience functions and Logger internals.
+// The standard library's log package is skipped as well, so messages
+// logged through StdLogger or Writer are attributed to their real caller.
+func externalCaller() (runtime.Frame, bool) {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if pkg := packageOf(frame.Function); pkg != thisPackage && pkg != "log" {
+			return frame, true
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+func packageOf(funcName string) string {
+	slash := strings.LastIndex(funcName, "/")
+	if slash == -1 {
+		slash = 0
+	}
+	dot := strings.Index(funcName[slash:], ".")
+	if dot == -1 {
+		return funcName
+	}
+	return funcName[:slash+dot]
+}
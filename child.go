@@ -0,0 +1,40 @@
+package simplelog
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+const inheritLevel = math.MinInt32
+
+// Named derives a child Logger that shares l's output, formatter and other
+// configuration but tags its messages with name (appended to l's prefix,
+// ie. "server.http") and keeps l's fields.
+//
+// The child follows l's level, so changing l's level cascades to it, until
+// SetLevel is called on the child to give it a level of its own.
+func (l *Logger) Named(name string) *Logger {
+	l.Lock()
+	prefix := l.prefix
+	l.Unlock()
+	if prefix != "" {
+		name = prefix + "." + name
+	}
+
+	ownLevel := int32(inheritLevel)
+	return &Logger{
+		core:     l.core,
+		fields:   l.fields,
+		prefix:   name,
+		ownLevel: &ownLevel,
+		inherits: l,
+	}
+}
+
+// InheritLevel discards the level set on a child Logger so that it follows
+// its parent's level again, it has no effect on Loggers not created by Named
+func (l *Logger) InheritLevel() {
+	if l.ownLevel != nil {
+		atomic.StoreInt32(l.ownLevel, inheritLevel)
+	}
+}
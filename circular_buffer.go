@@ -0,0 +1,62 @@
+package simplelog
+
+import (
+	"bytes"
+	"sync"
+)
+
+// CircularBuffer is an in-memory io.ReadWriter bounded to a maximum size.
+//
+// When a write would grow the buffer beyond maxBytes the oldest complete
+// lines are discarded to make room, which makes it handy for capturing the
+// tail of log output in tests without unbounded memory growth.
+type CircularBuffer struct {
+	sync.Mutex
+	buf      bytes.Buffer
+	maxBytes int
+}
+
+// NewCircularBuffer creates a new CircularBuffer holding at most maxBytes
+func NewCircularBuffer(maxBytes int) *CircularBuffer {
+	return &CircularBuffer{maxBytes: maxBytes}
+}
+
+// Write appends p to the buffer, discarding the oldest lines if necessary
+func (c *CircularBuffer) Write(p []byte) (int, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.buf.Write(p)
+	for c.buf.Len() > c.maxBytes {
+		data := c.buf.Bytes()
+		idx := bytes.IndexByte(data, '\n')
+		if idx == -1 || idx+1 == len(data) {
+			// no complete line left to drop, keep the newest bytes
+			c.buf.Next(len(data) - c.maxBytes)
+			break
+		}
+		c.buf.Next(idx + 1)
+	}
+	return len(p), nil
+}
+
+// Read reads (and consumes) the oldest data in the buffer
+func (c *CircularBuffer) Read(p []byte) (int, error) {
+	c.Lock()
+	defer c.Unlock()
+	return c.buf.Read(p)
+}
+
+// String returns the current contents of the buffer
+func (c *CircularBuffer) String() string {
+	c.Lock()
+	defer c.Unlock()
+	return c.buf.String()
+}
+
+// Reset clears the buffer
+func (c *CircularBuffer) Reset() {
+	c.Lock()
+	c.buf.Reset()
+	c.Unlock()
+}
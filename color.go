@@ -0,0 +1,110 @@
+package simplelog
+
+import (
+	"strconv"
+)
+
+// ColorMode controls when ANSI colors are emitted
+type ColorMode int
+
+const (
+	// ColorAuto enables colors only when the output is a terminal
+	ColorAuto ColorMode = iota
+	// ColorAlways forces colors on, ie. when piping through less -R
+	ColorAlways
+	// ColorNever disables colors
+	ColorNever
+)
+
+// SetColor sets when colors are used, overriding terminal detection
+func (l *Logger) SetColor(mode ColorMode) {
+	l.Lock()
+	l.colorMode = mode
+	l.Unlock()
+}
+
+// SetColor sets the color mode of the default (global) logger
+func SetColor(mode ColorMode) {
+	defaultLogger.Load().SetColor(mode)
+}
+
+// ColorScheme maps levels to the ANSI escape sequence used to color their
+// [LEVEL ...] bracket, ie. "\033[0;35;49m" for magenta.  Only the bracket is
+// colored, the message is left as is.
+type ColorScheme map[int]string
+
+// SetColorScheme overrides the colors of the levels in scheme, levels not in
+// scheme keep their default color.  A nil scheme restores the defaults.
+func (l *Logger) SetColorScheme(scheme ColorScheme) {
+	var colors map[int]string
+	if scheme != nil {
+		colors = make(map[int]string, len(scheme))
+		for level, color := range scheme {
+			colors[level] = color
+		}
+	}
+	l.Lock()
+	l.colorScheme = colors
+	l.Unlock()
+}
+
+// SetLevelColor overrides the color of level, ie:
+//
+//	l.SetLevelColor(simplelog.WARNING, simplelog.Color256(208))
+//
+// An empty color restores the default color of level.
+func (l *Logger) SetLevelColor(level int, color string) {
+	l.Lock()
+	defer l.Unlock()
+	if color == "" {
+		delete(l.colorScheme, level)
+		return
+	}
+	if l.colorScheme == nil {
+		l.colorScheme = make(map[int]string)
+	}
+	l.colorScheme[level] = color
+}
+
+// Color256 returns the escape sequence for color n of the 256 color palette
+func Color256(n uint8) string {
+	return "\033[38;5;" + strconv.Itoa(int(n)) + "m"
+}
+
+// TrueColor returns the escape sequence for a 24-bit RGB color
+func TrueColor(r, g, b uint8) string {
+	return "\033[38;2;" + strconv.Itoa(int(r)) + ";" + strconv.Itoa(int(g)) + ";" + strconv.Itoa(int(b)) + "m"
+}
+
+// SetLevelColor overrides the color of level on the default (global) logger
+func SetLevelColor(level int, color string) {
+	defaultLogger.Load().SetLevelColor(level, color)
+}
+
+// SetColorScheme sets the color scheme of the default (global) logger
+func SetColorScheme(scheme ColorScheme) {
+	defaultLogger.Load().SetColorScheme(scheme)
+}
+
+// levelColor returns the color of level, it must be called with the
+// Logger's lock held
+func (l *Logger) levelColor(level int) string {
+	if color, ok := l.colorScheme[level]; ok {
+		return color
+	}
+	color, _ := parseLevel(level)
+	return color
+}
+
+// colored reports whether messages at level are colored, it must be called
+// with the Logger's lock held
+func (l *Logger) colored(level int) bool {
+	switch l.colorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	_, istty := l.outputFor(level)
+	return istty
+}
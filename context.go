@@ -0,0 +1,90 @@
+package simplelog
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying fields, in addition to any
+// fields already stored in ctx, to be attached by the *Ctx logging methods
+func NewContext(ctx context.Context, fields Fields) context.Context {
+	existing := FromContext(ctx)
+	merged := make(Fields, len(existing)+len(fields))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// FromContext returns the fields stored in ctx by NewContext
+func FromContext(ctx context.Context) Fields {
+	fields, _ := ctx.Value(contextKey{}).(Fields)
+	return fields
+}
+
+// LogCtx logs a message at level with the fields stored in ctx attached
+func (l *Logger) LogCtx(ctx context.Context, level int, s string, args ...interface{}) {
+	if !l.maybeEnabled(level) {
+		return
+	}
+	l.With(FromContext(ctx)).Log(level, s, args...)
+}
+
+// TraceCtx logs a TRACE message with the fields stored in ctx attached
+func (l *Logger) TraceCtx(ctx context.Context, s string, args ...interface{}) {
+	l.LogCtx(ctx, TRACE, s, args...)
+}
+
+// DebugCtx logs a DEBUG message with the fields stored in ctx attached
+func (l *Logger) DebugCtx(ctx context.Context, s string, args ...interface{}) {
+	l.LogCtx(ctx, DEBUG, s, args...)
+}
+
+// InfoCtx logs an INFO message with the fields stored in ctx attached
+func (l *Logger) InfoCtx(ctx context.Context, s string, args ...interface{}) {
+	l.LogCtx(ctx, INFO, s, args...)
+}
+
+// WarningCtx logs a WARNING message with the fields stored in ctx attached
+func (l *Logger) WarningCtx(ctx context.Context, s string, args ...interface{}) {
+	l.LogCtx(ctx, WARNING, s, args...)
+}
+
+// ErrorCtx logs an ERROR message with the fields stored in ctx attached
+func (l *Logger) ErrorCtx(ctx context.Context, s string, args ...interface{}) {
+	l.LogCtx(ctx, ERROR, s, args...)
+}
+
+// TraceCtx is a convenience method to log a TRACE message with the fields
+// stored in ctx on the default (global) logger
+func TraceCtx(ctx context.Context, s string, args ...interface{}) {
+	defaultLogger.Load().LogCtx(ctx, TRACE, s, args...)
+}
+
+// DebugCtx is a convenience method to log a DEBUG message with the fields
+// stored in ctx on the default (global) logger
+func DebugCtx(ctx context.Context, s string, args ...interface{}) {
+	defaultLogger.Load().LogCtx(ctx, DEBUG, s, args...)
+}
+
+// InfoCtx is a convenience method to log an INFO message with the fields
+// stored in ctx on the default (global) logger
+func InfoCtx(ctx context.Context, s string, args ...interface{}) {
+	defaultLogger.Load().LogCtx(ctx, INFO, s, args...)
+}
+
+// WarningCtx is a convenience method to log a WARNING message with the
+// fields stored in ctx on the default (global) logger
+func WarningCtx(ctx context.Context, s string, args ...interface{}) {
+	defaultLogger.Load().LogCtx(ctx, WARNING, s, args...)
+}
+
+// ErrorCtx is a convenience method to log an ERROR message with the fields
+// stored in ctx on the default (global) logger
+func ErrorCtx(ctx context.Context, s string, args ...interface{}) {
+	defaultLogger.Load().LogCtx(ctx, ERROR, s, args...)
+}
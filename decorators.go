@@ -0,0 +1,45 @@
+package simplelog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Decorator is an ANSI text attribute applied, in addition to color, to
+// messages of a given level
+type Decorator int
+
+const (
+	Bold      Decorator = 1
+	Italic    Decorator = 3
+	Underline Decorator = 4
+	Blink     Decorator = 5
+	Strike    Decorator = 9
+)
+
+// SetLevelDecorators sets the text decorators applied to messages logged at
+// level, replacing any previously set.  Decorators are only applied when
+// colors are enabled.
+func (l *Logger) SetLevelDecorators(level int, decorators ...Decorator) {
+	l.Lock()
+	defer l.Unlock()
+	if l.decorators == nil {
+		l.decorators = make(map[int]string)
+	}
+	if len(decorators) == 0 {
+		delete(l.decorators, level)
+		return
+	}
+	codes := make([]string, len(decorators))
+	for i, d := range decorators {
+		codes[i] = strconv.Itoa(int(d))
+	}
+	l.decorators[level] = "\033[" + strings.Join(codes, ";") + "m"
+}
+
+// ResetDecorators removes all decorators for level
+func (l *Logger) ResetDecorators(level int) {
+	l.Lock()
+	delete(l.decorators, level)
+	l.Unlock()
+}
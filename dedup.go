@@ -0,0 +1,93 @@
+package simplelog
+
+import (
+	"time"
+)
+
+type deduper struct {
+	window    time.Duration
+	level     int
+	msg       string
+	fieldsKey string
+	start     time.Time
+	repeats   int
+
+	// the prefix and fields of the last message, its summary is logged
+	// with them
+	prefix string
+	fields Fields
+}
+
+// SetDedupWindow collapses consecutive identical messages (same level, text
+// and fields) logged within window of the first into that first message.  The
+// repeats are reported as a single "last message repeated N times" line
+// once a different message is logged, the window elapses or the Logger is
+// flushed.  A window of 0 disables deduplication.
+func (l *Logger) SetDedupWindow(window time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.flushDedup()
+	if window <= 0 {
+		l.dedup = nil
+		return
+	}
+	l.dedup = &deduper{window: window}
+}
+
+// SetDedupWindow sets the deduplication window of the default (global)
+// logger
+func SetDedupWindow(window time.Duration) {
+	defaultLogger.Load().SetDedupWindow(window)
+}
+
+// check returns whether a message from l should be emitted, along with a
+// copy of the previous state whose repeats must be reported first
+func (d *deduper) check(l *Logger, level int, msg string, now time.Time) (bool, deduper) {
+	fieldsKey := formatFields(l.fields)
+	if level == d.level && msg == d.msg && fieldsKey == d.fieldsKey && now.Sub(d.start) < d.window {
+		d.repeats++
+		return false, deduper{}
+	}
+	prev := *d
+	d.level = level
+	d.msg = msg
+	d.fieldsKey = fieldsKey
+	d.start = now
+	d.repeats = 0
+	d.prefix = l.prefix
+	d.fields = l.fields
+	return true, prev
+}
+
+// dedupe applies the deduplication window to a message, it must be called
+// with the Logger's lock held
+func (l *Logger) dedupe(level int, msg string, now time.Time) bool {
+	emit, prev := l.dedup.check(l, level, msg, now)
+	if prev.repeats > 0 {
+		l.logRepeated(prev)
+	}
+	return emit
+}
+
+// flushDedup reports any pending repeats, it must be called with the
+// Logger's lock held
+func (l *Logger) flushDedup() {
+	if l.dedup == nil || l.dedup.repeats == 0 {
+		return
+	}
+	prev := *l.dedup
+	l.dedup.repeats = 0
+	l.logRepeated(prev)
+}
+
+// logRepeated must be called with the Logger's lock held, the summary is
+// logged with the prefix and fields of the repeated message
+func (l *Logger) logRepeated(prev deduper) {
+	// the summary itself is not subject to deduplication
+	d := l.dedup
+	l.dedup = nil
+	rl := &Logger{core: l.core, fields: prev.fields, prefix: prev.prefix, ownLevel: l.ownLevel, inherits: l.inherits}
+	rl.log(prev.level, "last message repeated %d times", []interface{}{prev.repeats})
+	l.dedup = d
+}
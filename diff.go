@@ -0,0 +1,60 @@
+package simplelog
+
+import (
+	"strings"
+)
+
+const dim = "\033[2m"
+
+// SetDiffMode toggles highlighting the words that changed between
+// consecutive DEBUG messages.  Changed words are shown in the DEBUG color
+// and unchanged words are dimmed, this only applies when colors are
+// enabled.
+func (l *Logger) SetDiffMode(enabled bool) {
+	l.Lock()
+	l.diffMode = enabled
+	l.prevDebugMsg = ""
+	l.hasPrevDebugMsg = false
+	l.Unlock()
+}
+
+// diffWords returns cur with the words not present in the longest common
+// subsequence of words shared with prev wrapped in color and the rest dimmed
+func diffWords(prev string, cur string, color string) string {
+	a := strings.Split(prev, " ")
+	b := strings.Split(cur, " ")
+
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	words := make([]string, 0, len(b))
+	i, j := 0, 0
+	for j < len(b) {
+		switch {
+		case i < len(a) && a[i] == b[j]:
+			words = append(words, dim+b[j]+reset)
+			i++
+			j++
+		case i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			words = append(words, color+b[j]+reset)
+			j++
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,56 @@
+package simplelog
+
+import (
+	"os"
+	"strings"
+)
+
+var defaultLevelEmoji = map[int]string{
+	TRACE:   "🔍",
+	DEBUG:   "🐛",
+	INFO:    "ℹ️",
+	WARNING: "⚠️",
+	ERROR:   "❌",
+	FATAL:   "💀",
+	PANIC:   "🚨",
+}
+
+// SetEmojiLevels toggles replacing the level name with an emoji when writing
+// to a terminal whose locale supports UTF-8
+func (l *Logger) SetEmojiLevels(enabled bool) {
+	l.Lock()
+	l.emojiLevels = enabled
+	l.Unlock()
+}
+
+// SetLevelEmoji overrides the emoji used for level when emoji levels
+// are enabled
+func (l *Logger) SetLevelEmoji(level int, emoji string) {
+	l.Lock()
+	defer l.Unlock()
+	if l.levelEmoji == nil {
+		l.levelEmoji = make(map[int]string)
+	}
+	l.levelEmoji[level] = emoji
+}
+
+// levelEmojiFor must be called with the Logger's lock held
+func (l *Logger) levelEmojiFor(level int) (string, bool) {
+	if emoji, ok := l.levelEmoji[level]; ok {
+		return emoji, true
+	}
+	emoji, ok := defaultLevelEmoji[level]
+	return emoji, ok
+}
+
+func isUnicodeLocale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		v := os.Getenv(name)
+		if v == "" {
+			continue
+		}
+		v = strings.ToUpper(v)
+		return strings.Contains(v, "UTF-8") || strings.Contains(v, "UTF8")
+	}
+	return false
+}
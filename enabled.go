@@ -0,0 +1,66 @@
+package simplelog
+
+import (
+	"sync/atomic"
+)
+
+// IsEnabled reports whether a message at level would be logged, so that
+// callers can avoid constructing expensive arguments, ie:
+//
+//	if l.IsEnabled(simplelog.DEBUG) {
+//		l.Debug("state: %s", dumpState())
+//	}
+func (l *Logger) IsEnabled(level int) bool {
+	if atomic.LoadInt32(&l.hasPackageLevels) == 0 {
+		return level >= l.getLevel()
+	}
+	l.Lock()
+	defer l.Unlock()
+	return level >= l.effectiveLevel()
+}
+
+// maybeEnabled is the allocation free fast path check, it only returns false
+// when a message at level is certainly suppressed
+func (l *Logger) maybeEnabled(level int) bool {
+	return level >= l.getLevel() || atomic.LoadInt32(&l.hasPackageLevels) != 0
+}
+
+// Verbose logs at a fixed level only when that level is enabled, see V
+type Verbose struct {
+	l     *Logger
+	level int
+}
+
+// V returns a Verbose for level, for guarded logging:
+//
+//	if v := l.V(simplelog.DEBUG); v.Enabled() {
+//		v.Log("state: %s", dumpState())
+//	}
+func (l *Logger) V(level int) Verbose {
+	return Verbose{l: l, level: level}
+}
+
+// Enabled reports whether the Verbose's level is enabled
+func (v Verbose) Enabled() bool {
+	return v.l.IsEnabled(v.level)
+}
+
+// Log logs a message at the Verbose's level
+func (v Verbose) Log(s string, args ...interface{}) {
+	v.l.Log(v.level, s, args...)
+}
+
+// Logln logs args, separated by spaces, at the Verbose's level
+func (v Verbose) Logln(args ...interface{}) {
+	v.l.Logln(v.level, args...)
+}
+
+// IsEnabled reports whether level is enabled on the default (global) logger
+func IsEnabled(level int) bool {
+	return defaultLogger.Load().IsEnabled(level)
+}
+
+// V returns a Verbose for level on the default (global) logger
+func V(level int) Verbose {
+	return defaultLogger.Load().V(level)
+}
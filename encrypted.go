@@ -0,0 +1,131 @@
+package simplelog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"sync"
+)
+
+// EncryptedWriter is an io.Writer that encrypts each line written to it
+// with AES-256-GCM and writes it base64 encoded, one line per input line,
+// to the underlying writer.
+type EncryptedWriter struct {
+	sync.Mutex
+	w       io.Writer
+	aead    cipher.AEAD
+	pending []byte
+}
+
+// NewEncryptedWriter creates a new EncryptedWriter writing to w, key must be
+// 32 bytes long
+func NewEncryptedWriter(w io.Writer, key []byte) (*EncryptedWriter, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedWriter{w: w, aead: aead}, nil
+}
+
+// Write buffers p and encrypts every complete line it contains
+func (e *EncryptedWriter) Write(p []byte) (int, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	e.pending = append(e.pending, p...)
+	for {
+		idx := bytes.IndexByte(e.pending, '\n')
+		if idx == -1 {
+			break
+		}
+		err := e.writeLine(e.pending[:idx])
+		e.pending = e.pending[idx+1:]
+		if err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+func (e *EncryptedWriter) writeLine(line []byte) error {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sealed := e.aead.Seal(nonce, nonce, line, nil)
+
+	out := make([]byte, base64.StdEncoding.EncodedLen(len(sealed))+1)
+	base64.StdEncoding.Encode(out, sealed)
+	out[len(out)-1] = '\n'
+	_, err := e.w.Write(out)
+	return err
+}
+
+// DecryptedReader is an io.Reader that decrypts lines written by an
+// EncryptedWriter
+type DecryptedReader struct {
+	scanner *bufio.Scanner
+	aead    cipher.AEAD
+	pending []byte
+}
+
+// NewDecryptedReader creates a new DecryptedReader reading from r, key must
+// be the same 32 byte key used by the EncryptedWriter
+func NewDecryptedReader(r io.Reader, key []byte) (*DecryptedReader, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &DecryptedReader{scanner: bufio.NewScanner(r), aead: aead}, nil
+}
+
+// Read reads decrypted, newline terminated, log lines
+func (d *DecryptedReader) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		if !d.scanner.Scan() {
+			if err := d.scanner.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		line, err := d.decryptLine(d.scanner.Bytes())
+		if err != nil {
+			return 0, err
+		}
+		d.pending = append(line, '\n')
+	}
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
+
+func (d *DecryptedReader) decryptLine(line []byte) ([]byte, error) {
+	sealed := make([]byte, base64.StdEncoding.DecodedLen(len(line)))
+	n, err := base64.StdEncoding.Decode(sealed, line)
+	if err != nil {
+		return nil, err
+	}
+	sealed = sealed[:n]
+
+	nonceSize := d.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("encrypted line too short")
+	}
+	return d.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, errors.New("key must be 32 bytes for AES-256")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
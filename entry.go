@@ -0,0 +1,107 @@
+package simplelog
+
+// Entry accumulates fields for a single message, for chained construction:
+//
+//	l.WithField("id", id).WithError(err).Warning("retrying")
+//
+// Fields are only merged and rendered when a logging method is called and
+// the level is enabled.
+type Entry struct {
+	l      *Logger
+	parent *Entry
+	key    string
+	value  interface{}
+}
+
+// WithField returns an Entry logging through l with key set to value
+func (l *Logger) WithField(key string, value interface{}) *Entry {
+	return &Entry{l: l, key: key, value: value}
+}
+
+// WithField returns an Entry logging through the default (global) logger
+// with key set to value
+func WithField(key string, value interface{}) *Entry {
+	return defaultLogger.Load().WithField(key, value)
+}
+
+// WithField returns a new Entry with key set to value in addition to e's
+// fields
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	return &Entry{l: e.l, parent: e, key: key, value: value}
+}
+
+// WithFields returns a new Entry with the typed fields added to e's fields
+func (e *Entry) WithFields(fields ...Field) *Entry {
+	for _, f := range fields {
+		e = e.WithField(f.Key, f.Value)
+	}
+	return e
+}
+
+// WithError returns a new Entry with err added to e's fields, as
+// Logger.WithError does
+func (e *Entry) WithError(err error) *Entry {
+	if err == nil {
+		return e
+	}
+	for k, v := range errorFields(err) {
+		e = e.WithField(k, v)
+	}
+	return e
+}
+
+// logger returns a Logger carrying the Entry's fields, later fields
+// override earlier ones with the same key
+func (e *Entry) logger() *Logger {
+	fields := make(Fields)
+	for x := e; x != nil; x = x.parent {
+		if _, ok := fields[x.key]; !ok {
+			fields[x.key] = x.value
+		}
+	}
+	return e.l.With(fields)
+}
+
+// Log logs a message at level with the Entry's fields
+func (e *Entry) Log(level int, s string, args ...interface{}) {
+	if !e.l.maybeEnabled(level) {
+		return
+	}
+	e.logger().Log(level, s, args...)
+}
+
+// Trace logs a TRACE message with the Entry's fields
+func (e *Entry) Trace(s string, args ...interface{}) {
+	e.Log(TRACE, s, args...)
+}
+
+// Debug logs a DEBUG message with the Entry's fields
+func (e *Entry) Debug(s string, args ...interface{}) {
+	e.Log(DEBUG, s, args...)
+}
+
+// Info logs an INFO message with the Entry's fields
+func (e *Entry) Info(s string, args ...interface{}) {
+	e.Log(INFO, s, args...)
+}
+
+// Warning logs a WARNING message with the Entry's fields
+func (e *Entry) Warning(s string, args ...interface{}) {
+	e.Log(WARNING, s, args...)
+}
+
+// Error logs an ERROR message with the Entry's fields
+func (e *Entry) Error(s string, args ...interface{}) {
+	e.Log(ERROR, s, args...)
+}
+
+// Fatal logs a FATAL message with the Entry's fields and exits, see
+// Logger.Fatal
+func (e *Entry) Fatal(s string, args ...interface{}) {
+	e.logger().Fatal(s, args...)
+}
+
+// Panic logs a PANIC message with the Entry's fields and then panics
+func (e *Entry) Panic(s string, args ...interface{}) {
+	e.logger().Panic(s, args...)
+}
@@ -0,0 +1,93 @@
+package simplelog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ConfigureFromEnv configures l from the environment:
+//
+//	SIMPLELOG_LEVEL   any level accepted by SetLevel, ie. debug
+//	SIMPLELOG_FORMAT  text, json or logfmt
+//	SIMPLELOG_COLOR   auto, always or never
+//	SIMPLELOG_OUTPUT  stderr, stdout or the path of a file to append to
+//
+// Unset variables leave the corresponding setting unchanged.  If any
+// variable is invalid an error is returned and nothing is changed.
+func (l *Logger) ConfigureFromEnv() error {
+	level, hasLevel := os.LookupEnv("SIMPLELOG_LEVEL")
+	if hasLevel {
+		if _, ok := lookupLevel(strings.ToLower(level)); !ok {
+			return fmt.Errorf("invalid SIMPLELOG_LEVEL %q", level)
+		}
+	}
+
+	format, hasFormat := os.LookupEnv("SIMPLELOG_FORMAT")
+	var f Format
+	if hasFormat {
+		switch strings.ToLower(format) {
+		case "text":
+			f = TextFormat
+		case "json":
+			f = JSONFormat
+		case "logfmt":
+			f = LogfmtFormat
+		default:
+			return fmt.Errorf("invalid SIMPLELOG_FORMAT %q", format)
+		}
+	}
+
+	color, hasColor := os.LookupEnv("SIMPLELOG_COLOR")
+	var mode ColorMode
+	if hasColor {
+		switch strings.ToLower(color) {
+		case "auto":
+			mode = ColorAuto
+		case "always":
+			mode = ColorAlways
+		case "never":
+			mode = ColorNever
+		default:
+			return fmt.Errorf("invalid SIMPLELOG_COLOR %q", color)
+		}
+	}
+
+	output, hasOutput := os.LookupEnv("SIMPLELOG_OUTPUT")
+	var w io.Writer
+	if hasOutput {
+		switch output {
+		case "stderr":
+			w = os.Stderr
+		case "stdout":
+			w = os.Stdout
+		default:
+			file, err := os.OpenFile(output, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+			if err != nil {
+				return fmt.Errorf("invalid SIMPLELOG_OUTPUT: %s", err)
+			}
+			w = file
+		}
+	}
+
+	if hasLevel {
+		l.SetLevel(level)
+	}
+	if hasFormat {
+		l.SetFormat(f)
+	}
+	if hasColor {
+		l.SetColor(mode)
+	}
+	if hasOutput {
+		l.SetOutput(w)
+	}
+	return nil
+}
+
+// ConfigureFromEnv configures the default (global) logger from the
+// environment
+func ConfigureFromEnv() error {
+	return defaultLogger.Load().ConfigureFromEnv()
+}
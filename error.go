@@ -0,0 +1,67 @@
+package simplelog
+
+import (
+	"errors"
+)
+
+// WithError returns a Logger that attaches err to every message it logs as
+// the "error" field.  When err wraps other errors the innermost one is
+// also attached as "error.cause".  A nil err returns l unchanged.
+func (l *Logger) WithError(err error) *Logger {
+	if err == nil {
+		return l
+	}
+	return l.With(errorFields(err))
+}
+
+// errorFields returns the "error" and, for wrapped errors, "error.cause"
+// fields for err
+func errorFields(err error) Fields {
+	fields := Fields{"error": err.Error()}
+	cause := err
+	for next := errors.Unwrap(cause); next != nil; next = errors.Unwrap(cause) {
+		cause = next
+	}
+	if cause != err {
+		fields["error.cause"] = cause.Error()
+	}
+	return fields
+}
+
+// WithError returns a Logger derived from the default (global) logger that
+// attaches err to every message
+func WithError(err error) *Logger {
+	return defaultLogger.Load().WithError(err)
+}
+
+// SetStackTraceLevel includes a stack trace with every message logged at
+// level or above, ie. SetStackTraceLevel(ERROR)
+func (l *Logger) SetStackTraceLevel(level int) {
+	l.Lock()
+	l.stackTraceLevel = level
+	l.stackTraces = true
+	l.Unlock()
+}
+
+// ResetStackTraceLevel stops including stack traces set up by
+// SetStackTraceLevel
+func (l *Logger) ResetStackTraceLevel() {
+	l.Lock()
+	l.stackTraces = false
+	l.Unlock()
+}
+
+// SetStackTraceLevel sets the stack trace level of the default (global)
+// logger
+func SetStackTraceLevel(level int) {
+	defaultLogger.Load().SetStackTraceLevel(level)
+}
+
+// wantsStackTrace reports whether a message at level with args should
+// include a stack trace, it must be called with the Logger's lock held
+func (l *Logger) wantsStackTrace(level int, args []interface{}) bool {
+	if l.stackTraces && level >= l.stackTraceLevel {
+		return true
+	}
+	return l.stackTraceOnWrappedError && level >= ERROR && hasWrappedError(args)
+}
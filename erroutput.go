@@ -0,0 +1,38 @@
+package simplelog
+
+import (
+	"io"
+)
+
+// SetErrorOutput sends messages at or above level to w instead of the
+// Logger's output, with its own terminal detection for colors.  For
+// example, the common CLI split of informational messages to stdout and
+// warnings and errors to stderr:
+//
+//	l.SetOutput(os.Stdout)
+//	l.SetErrorOutput(os.Stderr, simplelog.WARNING)
+//
+// A nil w sends all messages to the output again.
+func (l *Logger) SetErrorOutput(w io.Writer, level int) {
+	istty := writerIsTTY(w)
+
+	l.Lock()
+	l.errOut = w
+	l.errIstty = istty
+	l.errLevel = level
+	l.Unlock()
+}
+
+// SetErrorOutput sets the error output of the default (global) logger
+func SetErrorOutput(w io.Writer, level int) {
+	defaultLogger.Load().SetErrorOutput(w, level)
+}
+
+// outputFor returns the writer messages at level are written to and
+// whether it is a terminal, it must be called with the Logger's lock held
+func (l *Logger) outputFor(level int) (io.Writer, bool) {
+	if l.errOut != nil && level >= l.errLevel {
+		return l.errOut, l.errIstty
+	}
+	return l.out, l.istty
+}
@@ -0,0 +1,82 @@
+// This example demonstrates a simplelog Plugin that mirrors log entries to
+// a file and maintains an index of the byte offset of every line, per level.
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/mreiferson/go-simplelog"
+)
+
+// FileIndexerPlugin writes every entry to a file and records the offset
+// at which each line starts, grouped by level
+type FileIndexerPlugin struct {
+	sync.Mutex
+	path    string
+	f       *os.File
+	offset  int64
+	offsets map[int][]int64
+}
+
+func (p *FileIndexerPlugin) Name() string {
+	return "file-indexer"
+}
+
+func (p *FileIndexerPlugin) Init(l *simplelog.Logger) error {
+	f, err := os.Create(p.path)
+	if err != nil {
+		return err
+	}
+	p.f = f
+	p.offsets = make(map[int][]int64)
+	return nil
+}
+
+func (p *FileIndexerPlugin) Handle(entry simplelog.LogEntry) {
+	p.Lock()
+	defer p.Unlock()
+
+	line := fmt.Sprintf("%s %d %s\n", entry.Time.Format("2006-01-02 15:04:05.000000"),
+		entry.Level, entry.Message)
+	n, err := p.f.WriteString(line)
+	if err != nil {
+		return
+	}
+	p.offsets[entry.Level] = append(p.offsets[entry.Level], p.offset)
+	p.offset += int64(n)
+}
+
+func (p *FileIndexerPlugin) Close() error {
+	return p.f.Close()
+}
+
+// Offsets returns the starting offsets of the lines logged at level
+func (p *FileIndexerPlugin) Offsets(level int) []int64 {
+	p.Lock()
+	defer p.Unlock()
+	return append([]int64(nil), p.offsets[level]...)
+}
+
+func main() {
+	indexer := &FileIndexerPlugin{path: "indexed.log"}
+	if err := simplelog.RegisterPlugin(indexer); err != nil {
+		simplelog.Error("failed to register plugin - %s", err)
+		os.Exit(1)
+	}
+
+	simplelog.Info("starting up")
+	simplelog.Warning("disk usage at %d%%", 91)
+	simplelog.Info("ready")
+	simplelog.Error("lost connection")
+
+	if err := simplelog.UnregisterPlugin(indexer.Name()); err != nil {
+		simplelog.Error("failed to unregister plugin - %s", err)
+		os.Exit(1)
+	}
+
+	for _, level := range []int{simplelog.INFO, simplelog.WARNING, simplelog.ERROR} {
+		fmt.Printf("level %d lines start at offsets %v\n", level, indexer.Offsets(level))
+	}
+}
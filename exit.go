@@ -0,0 +1,58 @@
+package simplelog
+
+import (
+	"fmt"
+	"os"
+)
+
+// RegisterExitHandler adds a function to be run by Fatal before the process
+// exits, ie. to close database connections or remove PID files.  Handlers
+// run in the order they were registered.
+func (l *Logger) RegisterExitHandler(handler func()) {
+	l.Lock()
+	l.exitHandlers = append(l.exitHandlers, handler)
+	l.Unlock()
+}
+
+// SetExitFunc overrides the function Fatal calls to terminate the process
+// (os.Exit by default), ie. so that tests can intercept it
+func (l *Logger) SetExitFunc(exit func(code int)) {
+	l.Lock()
+	l.exitFunc = exit
+	l.Unlock()
+}
+
+// RegisterExitHandler adds an exit handler to the default (global) logger
+func RegisterExitHandler(handler func()) {
+	defaultLogger.Load().RegisterExitHandler(handler)
+}
+
+// SetExitFunc overrides the exit function of the default (global) logger
+func SetExitFunc(exit func(code int)) {
+	defaultLogger.Load().SetExitFunc(exit)
+}
+
+// exit runs the registered exit handlers and terminates the process
+func (l *Logger) exit(code int) {
+	l.Lock()
+	handlers := l.exitHandlers
+	exit := l.exitFunc
+	l.Unlock()
+
+	for _, handler := range handlers {
+		runExitHandler(handler)
+	}
+	if exit == nil {
+		exit = os.Exit
+	}
+	exit(code)
+}
+
+func runExitHandler(handler func()) {
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Fprintf(os.Stderr, "simplelog: exit handler panicked - %v\n", err)
+		}
+	}()
+	handler()
+}
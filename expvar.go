@@ -0,0 +1,28 @@
+package simplelog
+
+import (
+	"expvar"
+	"strconv"
+)
+
+type levelVar struct {
+	l *Logger
+}
+
+// String returns the current level name as a JSON string
+func (v levelVar) String() string {
+	_, levelTxt := parseLevel(v.l.getLevel())
+	return strconv.Quote(levelTxt)
+}
+
+// Set changes the level, accepting the same strings as SetLevel
+func (v levelVar) Set(lvl string) error {
+	return v.l.SetLevel(lvl)
+}
+
+// ExpvarLevel returns an expvar.Var reporting the Logger's current level,
+// suitable for expvar.Publish.  The returned value also has a Set(string)
+// error method for changing the level.
+func (l *Logger) ExpvarLevel() expvar.Var {
+	return levelVar{l: l}
+}
@@ -0,0 +1,70 @@
+package simplelog
+
+import (
+	"strconv"
+	"time"
+)
+
+// Field is a single typed key/value pair, see WithFields
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Duration returns a Field rendered as "1.5s" in text formats and as
+// nanoseconds in JSON
+func Duration(key string, d time.Duration) Field {
+	return Field{Key: key, Value: d}
+}
+
+// Bytes returns a Field rendered as "1.5MiB" in text formats and as a
+// number of bytes in JSON
+func Bytes(key string, n int64) Field {
+	return Field{Key: key, Value: byteSize(n)}
+}
+
+// Err returns an "error" Field holding err's message
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+// WithFields is like With but takes typed Fields, ie:
+//
+//	l.WithFields(simplelog.Duration("elapsed", d), simplelog.Bytes("size", n))
+func (l *Logger) WithFields(fields ...Field) *Logger {
+	m := make(Fields, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f.Value
+	}
+	return l.With(m)
+}
+
+// WithFields returns a Logger derived from the default (global) logger that
+// appends the typed fields to every message
+func WithFields(fields ...Field) *Logger {
+	return defaultLogger.Load().WithFields(fields...)
+}
+
+// byteSize is a number of bytes, it renders with binary units as a string
+// and as a plain number in JSON
+type byteSize int64
+
+// String implements fmt.Stringer
+func (n byteSize) String() string {
+	const units = "KMGTPE"
+	if n < 1024 && n > -1024 {
+		return strconv.FormatInt(int64(n), 10) + "B"
+	}
+	v := float64(n)
+	i := -1
+	for (v >= 1024 || v <= -1024) && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+	return strconv.FormatFloat(v, 'f', 1, 64) + units[i:i+1] + "iB"
+}
+
+// MarshalJSON implements json.Marshaler
+func (n byteSize) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(n), 10), nil
+}
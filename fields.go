@@ -0,0 +1,81 @@
+package simplelog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fields are key/value pairs attached to log messages
+type Fields map[string]interface{}
+
+// With returns a Logger that appends fields, rendered as key=value pairs,
+// to every message it logs.  The returned Logger shares its configuration
+// and output with l, fields of l are retained unless overridden.
+func (l *Logger) With(fields Fields) *Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	merged := make(Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	l.Lock()
+	prefix := l.prefix
+	l.Unlock()
+	return &Logger{
+		core:     l.core,
+		fields:   merged,
+		prefix:   prefix,
+		ownLevel: l.ownLevel,
+		inherits: l.inherits,
+		name:     l.name,
+	}
+}
+
+// With returns a Logger derived from the default (global) logger that
+// appends fields to every message
+func With(fields Fields) *Logger {
+	return defaultLogger.Load().With(fields)
+}
+
+// formatFields renders fields as " key=value" pairs sorted by key
+func formatFields(fields Fields) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	return string(appendFields(nil, fields))
+}
+
+// appendFields appends fields to b as " key=value" pairs sorted by key
+func appendFields(b []byte, fields Fields) []byte {
+	if len(fields) == 0 {
+		return b
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		b = append(b, ' ')
+		b = append(b, k...)
+		b = append(b, '=')
+		b = append(b, formatFieldValue(fields[k])...)
+	}
+	return b
+}
+
+func formatFieldValue(v interface{}) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,32 @@
+package simplelog
+
+import (
+	"strings"
+)
+
+// LevelFlag is a flag.Value controlling a Logger's level, it accepts the
+// same strings as SetLevel:
+//
+//	flag.Var(simplelog.LevelVar(logger), "log-level", "log level (debug, info, ...)")
+type LevelFlag struct {
+	l *Logger
+}
+
+// LevelVar returns a LevelFlag controlling l's level
+func LevelVar(l *Logger) *LevelFlag {
+	return &LevelFlag{l: l}
+}
+
+// String implements flag.Value, returning the current level name
+func (f *LevelFlag) String() string {
+	if f == nil || f.l == nil {
+		return ""
+	}
+	_, levelTxt := parseLevel(f.l.getLevel())
+	return strings.ToLower(levelTxt)
+}
+
+// Set implements flag.Value
+func (f *LevelFlag) Set(lvl string) error {
+	return f.l.SetLevel(lvl)
+}
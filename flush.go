@@ -0,0 +1,58 @@
+package simplelog
+
+import (
+	"io"
+	"os"
+)
+
+// Flush reports any pending sampled or deduplicated repeats, waits for
+// queued asynchronous messages to be written and then flushes (or syncs)
+// the output if it supports it
+func (l *Logger) Flush() error {
+	l.Lock()
+	defer l.Unlock()
+
+	l.flushSampler()
+	l.flushDedup()
+	l.drainAsync()
+	return l.flushOutput()
+}
+
+// Close writes any queued messages, stops asynchronous mode and closes the
+// outputs and handlers that implement io.Closer (os.Stdout and os.Stderr are
+// left open)
+func (l *Logger) Close() error {
+	l.Lock()
+	defer l.Unlock()
+
+	l.flushSampler()
+	l.flushDedup()
+	l.stopAsync()
+	err := l.flushOutput()
+
+	for _, out := range []io.Writer{l.out, l.errOut} {
+		if c, ok := out.(io.Closer); ok && out != os.Stdout && out != os.Stderr {
+			if cerr := c.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	for _, h := range l.handlers {
+		if c, ok := h.(io.Closer); ok {
+			if cerr := c.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	return err
+}
+
+// Flush flushes the default (global) logger
+func Flush() error {
+	return defaultLogger.Load().Flush()
+}
+
+// Close closes the default (global) logger
+func Close() error {
+	return defaultLogger.Load().Close()
+}
@@ -0,0 +1,133 @@
+package simplelog
+
+import (
+	"time"
+)
+
+// Formatter renders a log message into the line written to the output,
+// including the trailing newline.
+//
+// Structured fields are appended to msg as key=value pairs unless the
+// Formatter also implements FieldsFormatter.
+type Formatter interface {
+	Format(level int, t time.Time, msg string) []byte
+}
+
+// FieldsFormatter is implemented by Formatters that render structured
+// fields themselves
+type FieldsFormatter interface {
+	Formatter
+	FormatFields(level int, t time.Time, msg string, fields Fields) []byte
+}
+
+// TextFormatter renders the default Tornado style format, optionally
+// colored:
+//
+//	[INFO 2013-03-31 12:00:00.000000] message key=value
+type TextFormatter struct {
+	Color bool
+}
+
+// Format implements Formatter
+func (f TextFormatter) Format(level int, t time.Time, msg string) []byte {
+	return f.FormatFields(level, t, msg, nil)
+}
+
+// FormatFields implements FieldsFormatter
+func (f TextFormatter) FormatFields(level int, t time.Time, msg string, fields Fields) []byte {
+	prefix, levelTxt := parseLevel(level)
+	postfix := reset
+	if !f.Color {
+		prefix = ""
+		postfix = ""
+	}
+	b := make([]byte, 0, 64+len(msg))
+	b = append(b, prefix...)
+	b = append(b, '[')
+	b = append(b, levelTxt...)
+	b = append(b, ' ')
+	b = appendTimestamp(b, t)
+	b = append(b, ']')
+	b = append(b, postfix...)
+	b = append(b, ' ')
+	b = append(b, msg...)
+	b = appendFields(b, fields)
+	return append(b, '\n')
+}
+
+// Format selects one of the built-in output formats
+type Format int
+
+const (
+	// TextFormat is the default, colored, Tornado style format
+	TextFormat Format = iota
+	// JSONFormat emits one JSON object per line with level, ts, msg and
+	// any structured fields
+	JSONFormat
+	// LogfmtFormat emits time=... level=... msg=... followed by any
+	// structured fields as key=value pairs
+	LogfmtFormat
+)
+
+// SetFormatter sets the Formatter used to render messages, nil restores
+// the default text format
+func (l *Logger) SetFormatter(f Formatter) {
+	l.Lock()
+	l.formatter = f
+	l.Unlock()
+}
+
+// SetFormat sets one of the built-in output formats
+func (l *Logger) SetFormat(format Format) {
+	switch format {
+	case JSONFormat:
+		l.SetFormatter(JSONFormatter{})
+	case LogfmtFormat:
+		l.SetFormatter(LogfmtFormatter{})
+	default:
+		l.SetFormatter(nil)
+	}
+}
+
+// SetFormatter sets the Formatter of the default (global) logger
+func SetFormatter(f Formatter) {
+	defaultLogger.Load().SetFormatter(f)
+}
+
+// SetFormat sets the output format of the default (global) logger
+func SetFormat(format Format) {
+	defaultLogger.Load().SetFormat(format)
+}
+
+// appendLine appends the formatted line to b, it must be called with the
+// Logger's lock held
+func (l *Logger) appendLine(b []byte, level int, dt time.Time, msg string, caller string) []byte {
+	if l.formatter == nil {
+		return l.appendText(b, level, dt, msg, caller)
+	}
+	return append(b, formatEntry(l.formatter, LogEntry{
+		Level:   level,
+		Time:    dt,
+		Message: msg,
+		Fields:  l.fields,
+		Caller:  caller,
+	})...)
+}
+
+// formatEntry renders entry with f, the caller (if any) is passed as the
+// "caller" field
+func formatEntry(f Formatter, entry LogEntry) []byte {
+	fields := entry.Fields
+	if entry.Caller != "" {
+		fields = make(Fields, len(entry.Fields)+1)
+		for k, v := range entry.Fields {
+			fields[k] = v
+		}
+		fields["caller"] = entry.Caller
+	}
+
+	if ff, ok := f.(FieldsFormatter); ok {
+		return ff.FormatFields(entry.Level, entry.Time, entry.Message, fields)
+	}
+	return f.Format(entry.Level, entry.Time, entry.Message+formatFields(fields))
+}
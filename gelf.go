@@ -0,0 +1,180 @@
+package simplelog
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	gelfChunkSize = 8192
+	gelfMaxChunks = 128
+)
+
+var errGELFTooLarge = errors.New("GELF message too large")
+
+// GELFFormatter renders entries as GELF 1.1 JSON objects for Graylog, one
+// per line.  Fields are sent as additional fields, prefixed with "_".
+type GELFFormatter struct {
+	// Host is reported as the source of the messages, the hostname if empty
+	Host string
+}
+
+// Format implements Formatter
+func (f GELFFormatter) Format(level int, t time.Time, msg string) []byte {
+	return append(formatGELF(f.Host, level, t, msg, nil), '\n')
+}
+
+// FormatFields implements FieldsFormatter
+func (f GELFFormatter) FormatFields(level int, t time.Time, msg string, fields Fields) []byte {
+	return append(formatGELF(f.Host, level, t, msg, fields), '\n')
+}
+
+// formatGELF renders a message as a GELF JSON object without a trailing
+// newline
+func formatGELF(host string, level int, dt time.Time, msg string, fields Fields) []byte {
+	if host == "" {
+		host, _ = os.Hostname()
+	}
+
+	obj := make(map[string]interface{}, len(fields)+5)
+	for k, v := range fields {
+		if k == "id" {
+			// _id is reserved by GELF
+			k = "fields.id"
+		}
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		obj["_"+k] = v
+	}
+	obj["version"] = "1.1"
+	obj["host"] = host
+	obj["short_message"] = msg
+	obj["timestamp"] = float64(dt.UnixNano()) / float64(time.Second)
+	obj["level"] = syslogSeverity(level)
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		b, _ = json.Marshal(map[string]interface{}{
+			"version":       "1.1",
+			"host":          host,
+			"short_message": msg,
+			"timestamp":     obj["timestamp"],
+			"level":         obj["level"],
+			"_error":        "failed to marshal fields: " + err.Error(),
+		})
+	}
+	return b
+}
+
+// syslogSeverity maps a level to its syslog severity, as SyslogHandler does
+func syslogSeverity(level int) int {
+	switch {
+	case level <= DEBUG:
+		return 7
+	case level == INFO:
+		return 6
+	case level == WARNING:
+		return 4
+	case level == ERROR:
+		return 3
+	case level == FATAL:
+		return 2
+	}
+	return 0
+}
+
+// GELFHandler is a Handler that sends entries at or above its level to a
+// Graylog GELF UDP input, zlib compressed and chunked when larger than a
+// single datagram
+type GELFHandler struct {
+	sync.Mutex
+	conn  net.Conn
+	host  string
+	level int
+}
+
+// NewGELFHandler creates a GELFHandler sending entries at or above level to
+// the GELF UDP input at addr
+func NewGELFHandler(addr string, level int) (*GELFHandler, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	return &GELFHandler{conn: conn, host: host, level: level}, nil
+}
+
+// Handle implements Handler
+func (h *GELFHandler) Handle(entry LogEntry) error {
+	if entry.Level < h.level {
+		return nil
+	}
+
+	fields := entry.Fields
+	if entry.Caller != "" {
+		fields = make(Fields, len(entry.Fields)+1)
+		for k, v := range entry.Fields {
+			fields[k] = v
+		}
+		fields["caller"] = entry.Caller
+	}
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(formatGELF(h.host, entry.Level, entry.Time, entry.Message, fields))
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	return h.send(buf.Bytes())
+}
+
+// send writes msg as a single datagram, or as GELF chunks when it does not
+// fit, it must be called with the handler's lock held
+func (h *GELFHandler) send(msg []byte) error {
+	if len(msg) <= gelfChunkSize {
+		_, err := h.conn.Write(msg)
+		return err
+	}
+
+	const headerSize = 12
+	payloadSize := gelfChunkSize - headerSize
+	count := (len(msg) + payloadSize - 1) / payloadSize
+	if count > gelfMaxChunks {
+		return errGELFTooLarge
+	}
+
+	chunk := make([]byte, 0, gelfChunkSize)
+	chunk = append(chunk, 0x1e, 0x0f)
+	chunk = append(chunk, make([]byte, 8)...)
+	if _, err := rand.Read(chunk[2:10]); err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		end := (i + 1) * payloadSize
+		if end > len(msg) {
+			end = len(msg)
+		}
+		chunk = append(chunk[:10], byte(i), byte(count))
+		chunk = append(chunk, msg[i*payloadSize:end]...)
+		if _, err := h.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the UDP socket
+func (h *GELFHandler) Close() error {
+	return h.conn.Close()
+}
@@ -0,0 +1,98 @@
+package simplelog
+
+import (
+	"fmt"
+)
+
+// GRPCLogger adapts a Logger to grpc-go's grpclog.LoggerV2 interface so
+// that grpc's internal logging flows through simplelog:
+//
+//	grpclog.SetLoggerV2(simplelog.NewGRPCLogger(l))
+//
+// It satisfies the interface structurally, simplelog does not depend on
+// grpc-go.  Server interceptors logging every RPC are provided by the
+// grpclog subpackage.
+type GRPCLogger struct {
+	l *Logger
+}
+
+// NewGRPCLogger returns a GRPCLogger logging through l
+func NewGRPCLogger(l *Logger) *GRPCLogger {
+	return &GRPCLogger{l: l}
+}
+
+func (g *GRPCLogger) print(level int, args []interface{}) {
+	if !g.l.maybeEnabled(level) {
+		return
+	}
+	g.l.Log(level, "%s", fmt.Sprint(args...))
+}
+
+// Info logs args, formatted as by fmt.Sprint, as an INFO message
+func (g *GRPCLogger) Info(args ...interface{}) {
+	g.print(INFO, args)
+}
+
+// Infoln logs args, separated by spaces, as an INFO message
+func (g *GRPCLogger) Infoln(args ...interface{}) {
+	g.l.Logln(INFO, args...)
+}
+
+// Infof logs an INFO message
+func (g *GRPCLogger) Infof(s string, args ...interface{}) {
+	g.l.Log(INFO, s, args...)
+}
+
+// Warning logs args, formatted as by fmt.Sprint, as a WARNING message
+func (g *GRPCLogger) Warning(args ...interface{}) {
+	g.print(WARNING, args)
+}
+
+// Warningln logs args, separated by spaces, as a WARNING message
+func (g *GRPCLogger) Warningln(args ...interface{}) {
+	g.l.Logln(WARNING, args...)
+}
+
+// Warningf logs a WARNING message
+func (g *GRPCLogger) Warningf(s string, args ...interface{}) {
+	g.l.Log(WARNING, s, args...)
+}
+
+// Error logs args, formatted as by fmt.Sprint, as an ERROR message
+func (g *GRPCLogger) Error(args ...interface{}) {
+	g.print(ERROR, args)
+}
+
+// Errorln logs args, separated by spaces, as an ERROR message
+func (g *GRPCLogger) Errorln(args ...interface{}) {
+	g.l.Logln(ERROR, args...)
+}
+
+// Errorf logs an ERROR message
+func (g *GRPCLogger) Errorf(s string, args ...interface{}) {
+	g.l.Log(ERROR, s, args...)
+}
+
+// Fatal logs args, formatted as by fmt.Sprint, as a FATAL message and exits
+func (g *GRPCLogger) Fatal(args ...interface{}) {
+	g.l.Fatal("%s", fmt.Sprint(args...))
+}
+
+// Fatalln logs args, separated by spaces, as a FATAL message and exits
+func (g *GRPCLogger) Fatalln(args ...interface{}) {
+	g.l.Fatal(lnFormat(len(args)), args...)
+}
+
+// Fatalf logs a FATAL message and exits
+func (g *GRPCLogger) Fatalf(s string, args ...interface{}) {
+	g.l.Fatal(s, args...)
+}
+
+// V reports whether grpc verbosity level v is enabled, level 0 follows INFO
+// and higher levels follow DEBUG
+func (g *GRPCLogger) V(v int) bool {
+	if v <= 0 {
+		return g.l.IsEnabled(INFO)
+	}
+	return g.l.IsEnabled(DEBUG)
+}
@@ -0,0 +1,69 @@
+// Package grpclog provides gRPC server interceptors logging the method,
+// status code and latency of every RPC through simplelog:
+//
+//	s := grpc.NewServer(
+//		grpc.UnaryInterceptor(grpclog.UnaryServerInterceptor(logger)),
+//		grpc.StreamInterceptor(grpclog.StreamServerInterceptor(logger)),
+//	)
+//
+// RPCs are logged at INFO, or WARNING for client errors and ERROR for server
+// errors, like simplelog.HTTPHandler.  grpc-go's own logging is routed
+// through simplelog by simplelog.NewGRPCLogger.
+package grpclog
+
+import (
+	"context"
+	"time"
+
+	"github.com/mreiferson/go-simplelog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor logging every
+// unary RPC to l, a nil l logs to the default (global) logger
+func UnaryServerInterceptor(l *simplelog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logRPC(l, info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor logging
+// every streaming RPC to l once it completes, a nil l logs to the default
+// (global) logger
+func StreamServerInterceptor(l *simplelog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logRPC(l, info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+// logRPC logs an RPC in the style of simplelog.HTTPHandler:
+//
+//	[INFO 2013-03-31 12:00:00.000000] OK /pkg.Service/Method 1.23ms
+func logRPC(l *simplelog.Logger, method string, err error, latency time.Duration) {
+	code := status.Code(err)
+
+	level := simplelog.INFO
+	switch code {
+	case codes.OK:
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		level = simplelog.ERROR
+	default:
+		level = simplelog.WARNING
+	}
+
+	if l == nil {
+		l = simplelog.Default()
+	}
+	l.Log(level, "%s %s %.2fms", code, method, float64(latency)/float64(time.Millisecond))
+}
@@ -0,0 +1,84 @@
+package simplelog
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// Handler is an additional destination for the entries emitted by a Logger,
+// allowing a single Logger to fan out to several outputs (ie. a colored
+// terminal, a plain file and syslog) simultaneously.
+//
+// Handle is called, in the order handlers were added, for every entry at or
+// above the Logger's level.
+type Handler interface {
+	Handle(entry LogEntry) error
+}
+
+// AddHandler adds h to the destinations of the Logger
+func (l *Logger) AddHandler(h Handler) {
+	l.Lock()
+	l.handlers = append(l.handlers, h)
+	l.Unlock()
+}
+
+// RemoveHandler removes a Handler previously added with AddHandler
+func (l *Logger) RemoveHandler(h Handler) {
+	l.Lock()
+	defer l.Unlock()
+	for i, handler := range l.handlers {
+		if handler == h {
+			l.handlers = append(l.handlers[:i:i], l.handlers[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddHandler adds h to the destinations of the default (global) logger
+func AddHandler(h Handler) {
+	defaultLogger.Load().AddHandler(h)
+}
+
+// RemoveHandler removes h from the destinations of the default (global) logger
+func RemoveHandler(h Handler) {
+	defaultLogger.Load().RemoveHandler(h)
+}
+
+// WriterHandler is a Handler that formats entries at or above its own level
+// and writes them to an io.Writer
+type WriterHandler struct {
+	sync.Mutex
+	w         io.Writer
+	level     int
+	formatter Formatter
+}
+
+// NewWriterHandler creates a WriterHandler writing entries at or above level
+// to w using formatter.  A nil formatter selects the default text format,
+// colored when w is a terminal.
+func NewWriterHandler(w io.Writer, level int, formatter Formatter) *WriterHandler {
+	if formatter == nil {
+		f, ok := w.(*os.File)
+		formatter = TextFormatter{Color: ok && isatty(f)}
+	}
+	return &WriterHandler{
+		w:         w,
+		level:     level,
+		formatter: formatter,
+	}
+}
+
+// Handle implements Handler
+func (h *WriterHandler) Handle(entry LogEntry) error {
+	if entry.Level < h.level {
+		return nil
+	}
+
+	line := formatEntry(h.formatter, entry)
+
+	h.Lock()
+	defer h.Unlock()
+	_, err := h.w.Write(line)
+	return err
+}
@@ -0,0 +1,38 @@
+package simplelog
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Hook is fired for every entry emitted by a Logger, allowing side effects
+// such as incrementing metrics or forwarding errors to an alerting service.
+// Hooks that only care about certain levels should check level themselves.
+//
+// Fire is called with the Logger's lock held, it must not log to the same
+// Logger.
+type Hook interface {
+	Fire(level int, t time.Time, msg string, fields map[string]interface{}) error
+}
+
+// AddHook adds h to the hooks fired by the Logger
+func (l *Logger) AddHook(h Hook) {
+	l.Lock()
+	l.hooks = append(l.hooks, h)
+	l.Unlock()
+}
+
+// AddHook adds h to the hooks fired by the default (global) logger
+func AddHook(h Hook) {
+	defaultLogger.Load().AddHook(h)
+}
+
+// fireHooks must be called with the Logger's lock held
+func (l *Logger) fireHooks(entry LogEntry) {
+	for _, h := range l.hooks {
+		if err := h.Fire(entry.Level, entry.Time, entry.Message, entry.Fields); err != nil {
+			fmt.Fprintf(os.Stderr, "simplelog: failed to fire hook - %s\n", err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package simplelog
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// HTTPHandler wraps next, logging every request in the Tornado style:
+//
+//	[INFO 2013-03-31 12:00:00.000000] 200 GET /index.html (127.0.0.1) 1.23ms 512B
+//
+// Requests are logged at INFO, or WARNING for 4xx and ERROR for 5xx status
+// codes, so they are colored green, yellow and red.  A nil l logs to the
+// default (global) logger.
+func HTTPHandler(next http.Handler, l *Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r)
+		latency := time.Since(start)
+
+		level := INFO
+		switch {
+		case rw.status >= 500:
+			level = ERROR
+		case rw.status >= 400:
+			level = WARNING
+		}
+
+		remoteIP, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			remoteIP = r.RemoteAddr
+		}
+
+		logger := l
+		if logger == nil {
+			logger = defaultLogger.Load()
+		}
+		logger.Log(level, "%d %s %s (%s) %.2fms %dB", rw.status, r.Method,
+			r.URL.RequestURI(), remoteIP, float64(latency)/float64(time.Millisecond), rw.bytes)
+	})
+}
+
+// responseWriter records the status code and body size of a response
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (rw *responseWriter) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		rw.status = status
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *responseWriter) Write(p []byte) (int, error) {
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(p)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher when the underlying ResponseWriter does, so
+// streaming handlers keep working
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		rw.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, it fails when the underlying
+// ResponseWriter does not support hijacking
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("ResponseWriter does not implement http.Hijacker")
+	}
+	return h.Hijack()
+}
+
+// Unwrap allows http.ResponseController to reach the underlying
+// ResponseWriter, ie. to flush or hijack it
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,15 @@
+package simplelog
+
+// Interface is the subset of the Logger API needed to log, so libraries
+// can accept it and applications can pass a *Logger (including one from
+// NewNopLogger), a mock or an adapter to another logging backend
+type Interface interface {
+	Debug(s string, args ...interface{})
+	Info(s string, args ...interface{})
+	Warning(s string, args ...interface{})
+	Error(s string, args ...interface{})
+	Log(level int, s string, args ...interface{})
+	SetLevel(lvl interface{}) error
+}
+
+var _ Interface = (*Logger)(nil)
@@ -0,0 +1,144 @@
+//go:build linux
+
+package simplelog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const journalSocket = "/run/systemd/journal/socket"
+
+// JournalHandler is a Handler that writes entries at or above its level to
+// systemd-journald using its native protocol.  The level is passed as
+// PRIORITY and fields as journal fields (upper cased, ie. "user_id" becomes
+// USER_ID) instead of being appended to the message.  Fields colliding with
+// the MESSAGE, PRIORITY, SYSLOG_IDENTIFIER, CODE_FILE and CODE_LINE fields
+// are prefixed with FIELDS_.
+type JournalHandler struct {
+	conn       *net.UnixConn
+	addr       *net.UnixAddr
+	identifier string
+	level      int
+}
+
+// NewJournalHandler connects to the local journald, messages are tagged
+// with identifier (SYSLOG_IDENTIFIER, the program name if empty) and only
+// entries at or above level are written
+func NewJournalHandler(identifier string, level int) (*JournalHandler, error) {
+	if _, err := os.Stat(journalSocket); err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	if identifier == "" {
+		identifier = filepath.Base(os.Args[0])
+	}
+	return &JournalHandler{
+		conn:       conn,
+		addr:       &net.UnixAddr{Name: journalSocket, Net: "unixgram"},
+		identifier: identifier,
+		level:      level,
+	}, nil
+}
+
+// Handle implements Handler
+func (h *JournalHandler) Handle(entry LogEntry) error {
+	if entry.Level < h.level {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	appendJournalField(&buf, "MESSAGE", entry.Message)
+	appendJournalField(&buf, "PRIORITY", strconv.Itoa(syslogSeverity(entry.Level)))
+	appendJournalField(&buf, "SYSLOG_IDENTIFIER", h.identifier)
+	if i := strings.LastIndexByte(entry.Caller, ':'); i != -1 {
+		appendJournalField(&buf, "CODE_FILE", entry.Caller[:i])
+		appendJournalField(&buf, "CODE_LINE", entry.Caller[i+1:])
+	}
+	for k, v := range entry.Fields {
+		appendJournalField(&buf, journalFieldName(k), fmt.Sprint(v))
+	}
+
+	_, _, err := h.conn.WriteMsgUnix(buf.Bytes(), nil, h.addr)
+	if errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS) {
+		return h.sendLarge(buf.Bytes())
+	}
+	return err
+}
+
+// sendLarge passes data too large for a datagram through an unlinked
+// temporary file, as sd_journal_send does
+func (h *JournalHandler) sendLarge(data []byte) error {
+	f, err := os.CreateTemp("/dev/shm", "journal.")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	_, _, err = h.conn.WriteMsgUnix(nil, syscall.UnixRights(int(f.Fd())), h.addr)
+	return err
+}
+
+// Close closes the connection to journald
+func (h *JournalHandler) Close() error {
+	return h.conn.Close()
+}
+
+// appendJournalField appends a KEY=value field, values containing newlines
+// use the length prefixed binary form
+func appendJournalField(buf *bytes.Buffer, key string, value string) {
+	buf.WriteString(key)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalReserved are the journal fields written by JournalHandler itself
+var journalReserved = map[string]bool{
+	"MESSAGE":           true,
+	"PRIORITY":          true,
+	"SYSLOG_IDENTIFIER": true,
+	"CODE_FILE":         true,
+	"CODE_LINE":         true,
+}
+
+// journalFieldName converts a field key to a valid journal field name,
+// upper case letters, digits and underscores not starting with an
+// underscore or digit and not clashing with journalReserved
+func journalFieldName(key string) string {
+	name := []byte(strings.ToUpper(key))
+	for i, c := range name {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			name[i] = '_'
+		}
+	}
+	s := strings.TrimLeft(string(name), "_")
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		s = "FIELD_" + s
+	}
+	if journalReserved[s] {
+		s = "FIELDS_" + s
+	}
+	return s
+}
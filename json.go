@@ -0,0 +1,53 @@
+package simplelog
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// JSONFormatter renders one JSON object per line with level, ts, msg and
+// any structured fields as keys
+type JSONFormatter struct{}
+
+// Format implements Formatter
+func (f JSONFormatter) Format(level int, t time.Time, msg string) []byte {
+	return formatJSON(level, t, msg, nil)
+}
+
+// FormatFields implements FieldsFormatter
+func (f JSONFormatter) FormatFields(level int, t time.Time, msg string, fields Fields) []byte {
+	return formatJSON(level, t, msg, fields)
+}
+
+// formatJSON renders a message as a single line JSON object.  Fields
+// colliding with the level, ts and msg keys are prefixed with "fields."
+func formatJSON(level int, dt time.Time, msg string, fields Fields) []byte {
+	_, levelTxt := parseLevel(level)
+
+	obj := make(map[string]interface{}, len(fields)+3)
+	for k, v := range fields {
+		switch k {
+		case "level", "ts", "msg":
+			k = "fields." + k
+		}
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		obj[k] = v
+	}
+	obj["level"] = strings.ToLower(levelTxt)
+	obj["ts"] = dt.Format(time.RFC3339Nano)
+	obj["msg"] = msg
+
+	line, err := json.Marshal(obj)
+	if err != nil {
+		line, _ = json.Marshal(map[string]interface{}{
+			"level": obj["level"],
+			"ts":    obj["ts"],
+			"msg":   msg,
+			"error": "failed to marshal fields: " + err.Error(),
+		})
+	}
+	return append(line, '\n')
+}
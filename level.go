@@ -0,0 +1,80 @@
+package simplelog
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Level is a log level, ie. INFO
+type Level int
+
+// String returns the name of the level, ie. "INFO"
+func (lvl Level) String() string {
+	switch int(lvl) {
+	case TRACE, DEBUG, INFO, WARNING, ERROR, FATAL, PANIC:
+		_, levelTxt := parseLevel(int(lvl))
+		return levelTxt
+	}
+	return "Level(" + strconv.Itoa(int(lvl)) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the lowercase
+// level name.  encoding/json also uses it to encode a Level as a string.
+func (lvl Level) MarshalText() ([]byte, error) {
+	switch int(lvl) {
+	case TRACE, DEBUG, INFO, WARNING, ERROR, FATAL, PANIC:
+		return []byte(strings.ToLower(lvl.String())), nil
+	}
+	return nil, errors.New("invalid level")
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the same
+// strings as ParseLevel
+func (lvl *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*lvl = level
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting either a level name
+// or its number, ie. "debug" or 0
+func (lvl *Level) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return lvl.UnmarshalText([]byte(name))
+	}
+	var level int
+	if err := json.Unmarshal(data, &level); err != nil {
+		return errors.New("invalid level")
+	}
+	if level < TRACE || level > PANIC {
+		return errors.New("invalid level")
+	}
+	*lvl = Level(level)
+	return nil
+}
+
+// ParseLevel returns the Level named name, accepting the same strings as
+// SetLevel (case insensitive, including registered aliases)
+func ParseLevel(name string) (Level, error) {
+	level, ok := lookupLevel(strings.ToLower(name))
+	if !ok {
+		return 0, errors.New("invalid level")
+	}
+	return Level(level), nil
+}
+
+// Level returns the Logger's current level
+func (l *Logger) Level() int {
+	return l.getLevel()
+}
+
+// GetLevel returns the current level of the default (global) logger
+func GetLevel() int {
+	return defaultLogger.Load().getLevel()
+}
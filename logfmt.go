@@ -0,0 +1,56 @@
+package simplelog
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogfmtFormatter renders one logfmt line per message:
+//
+//	time=2013-03-31T12:00:00Z level=info msg="message" key=value
+type LogfmtFormatter struct{}
+
+// Format implements Formatter
+func (f LogfmtFormatter) Format(level int, t time.Time, msg string) []byte {
+	return formatLogfmt(level, t, msg, nil)
+}
+
+// FormatFields implements FieldsFormatter
+func (f LogfmtFormatter) FormatFields(level int, t time.Time, msg string, fields Fields) []byte {
+	return formatLogfmt(level, t, msg, fields)
+}
+
+// formatLogfmt renders a message as a logfmt line, fields follow the time,
+// level and msg keys sorted by key.  Fields colliding with those keys are
+// prefixed with "fields."
+func formatLogfmt(level int, dt time.Time, msg string, fields Fields) []byte {
+	_, levelTxt := parseLevel(level)
+
+	b := make([]byte, 0, 64+len(msg))
+	b = append(b, "time="...)
+	b = dt.AppendFormat(b, time.RFC3339Nano)
+	b = append(b, " level="...)
+	b = append(b, strings.ToLower(levelTxt)...)
+	b = append(b, " msg="...)
+	b = append(b, formatFieldValue(msg)...)
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		key := k
+		switch k {
+		case "time", "level", "msg":
+			key = "fields." + k
+		}
+		b = append(b, ' ')
+		b = append(b, key...)
+		b = append(b, '=')
+		b = append(b, formatFieldValue(fields[k])...)
+	}
+	return append(b, '\n')
+}
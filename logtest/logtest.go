@@ -0,0 +1,91 @@
+// Package logtest provides a Recorder capturing simplelog entries in memory
+// so applications can unit test their logging without parsing stderr.
+//
+//	rec := logtest.NewRecorder()
+//	logger := rec.Logger(simplelog.DEBUG)
+//	doWork(logger)
+//	if !rec.Contains(simplelog.ERROR, "connection refused") {
+//		t.Errorf("expected an ERROR, got %v", rec.Entries())
+//	}
+package logtest
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/mreiferson/go-simplelog"
+)
+
+// Recorder is a simplelog.Handler recording every entry it handles
+type Recorder struct {
+	sync.Mutex
+	entries []simplelog.LogEntry
+}
+
+// NewRecorder creates a new, empty, Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Logger returns a new Logger at level that records to r and writes
+// nothing to its output
+func (r *Recorder) Logger(level int) *simplelog.Logger {
+	l := simplelog.NewLogger(level)
+	l.SetOutput(io.Discard)
+	l.AddHandler(r)
+	return l
+}
+
+// Handle implements simplelog.Handler
+func (r *Recorder) Handle(entry simplelog.LogEntry) error {
+	r.Lock()
+	r.entries = append(r.entries, entry)
+	r.Unlock()
+	return nil
+}
+
+// Entries returns a copy of the recorded entries, oldest first
+func (r *Recorder) Entries() []simplelog.LogEntry {
+	r.Lock()
+	defer r.Unlock()
+	return append([]simplelog.LogEntry(nil), r.entries...)
+}
+
+// LastEntry returns the most recently recorded entry, ok is false when
+// nothing has been recorded
+func (r *Recorder) LastEntry() (entry simplelog.LogEntry, ok bool) {
+	r.Lock()
+	defer r.Unlock()
+	if len(r.entries) == 0 {
+		return entry, false
+	}
+	return r.entries[len(r.entries)-1], true
+}
+
+// Contains reports whether an entry at level whose message contains substr
+// has been recorded
+func (r *Recorder) Contains(level int, substr string) bool {
+	r.Lock()
+	defer r.Unlock()
+	for _, entry := range r.entries {
+		if entry.Level == level && strings.Contains(entry.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of recorded entries
+func (r *Recorder) Len() int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.entries)
+}
+
+// Reset discards all recorded entries
+func (r *Recorder) Reset() {
+	r.Lock()
+	r.entries = nil
+	r.Unlock()
+}
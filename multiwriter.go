@@ -0,0 +1,91 @@
+package simplelog
+
+import (
+	"io"
+	"os"
+)
+
+// multiWriter duplicates writes to several writers, stripping ANSI escape
+// sequences from the copies written to anything but a terminal
+type multiWriter struct {
+	writers []io.Writer
+	ttys    []bool
+}
+
+// MultiWriter returns an io.Writer duplicating its writes to all of
+// writers, for use with SetOutput.  Copies written to an *os.File attached
+// to a terminal keep their colors, the ANSI escape sequences are stripped
+// from all others, ie. to log colored to os.Stderr and plain to a file:
+//
+//	l.SetOutput(simplelog.MultiWriter(os.Stderr, f))
+func MultiWriter(writers ...io.Writer) io.Writer {
+	mw := &multiWriter{
+		writers: writers,
+		ttys:    make([]bool, len(writers)),
+	}
+	for i, w := range writers {
+		if f, ok := w.(*os.File); ok {
+			mw.ttys[i] = isatty(f)
+		}
+	}
+	return mw
+}
+
+// Write writes p to every writer, returning the first error encountered
+func (mw *multiWriter) Write(p []byte) (int, error) {
+	var firstErr error
+	var plain []byte
+	for i, w := range mw.writers {
+		out := p
+		if !mw.ttys[i] {
+			if plain == nil {
+				plain = stripANSI(p)
+			}
+			out = plain
+		}
+		if _, err := w.Write(out); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return 0, firstErr
+	}
+	return len(p), nil
+}
+
+// Flush flushes (or syncs) every writer supporting it
+func (mw *multiWriter) Flush() error {
+	var firstErr error
+	for _, w := range mw.writers {
+		if err := flushWriter(w); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (mw *multiWriter) hasTTY() bool {
+	for _, tty := range mw.ttys {
+		if tty {
+			return true
+		}
+	}
+	return false
+}
+
+// stripANSI returns a copy of p without ANSI CSI escape sequences
+func stripANSI(p []byte) []byte {
+	b := make([]byte, 0, len(p))
+	for i := 0; i < len(p); i++ {
+		if p[i] != '\033' || i+1 >= len(p) || p[i+1] != '[' {
+			b = append(b, p[i])
+			continue
+		}
+		// skip parameters up to and including the final byte
+		i += 2
+		for i < len(p) && (p[i] < 0x40 || p[i] > 0x7e) {
+			i++
+		}
+	}
+	return b
+}
@@ -0,0 +1,82 @@
+package simplelog
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var namedLoggers = make(map[string]*Logger)
+var namedLevels = make(map[string]int)
+var namedLock sync.Mutex
+
+// GetLogger returns the Logger registered under name, creating it if
+// necessary.  Names are dot separated (ie. "http.server") and a named
+// Logger without an explicit level inherits the level of its closest
+// ancestor ("http"), or, at the time each message is logged, that of the
+// default (global) logger.
+//
+// Named Loggers share the output, formatter, handlers and other
+// configuration of the default logger at the time they are created, so
+// SetDefault should be called before GetLogger.
+//
+// Calling SetLevel on a named Logger is equivalent to SetLoggerLevel.
+//
+// GetLogger is a process wide registry keyed by name, Logger.Named derives
+// an unregistered child from a specific Logger instead.  The two are
+// independent: levels set with SetLoggerLevel do not apply to children
+// created with Named, though a child created with Named from a registered
+// Logger follows that Logger's level.
+func GetLogger(name string) *Logger {
+	namedLock.Lock()
+	defer namedLock.Unlock()
+
+	if l, ok := namedLoggers[name]; ok {
+		return l
+	}
+	ownLevel := int32(inheritLevel)
+	if level, ok := resolveLevel(name); ok {
+		ownLevel = int32(level)
+	}
+	l := &Logger{
+		core:     defaultLogger.Load().core,
+		ownLevel: &ownLevel,
+		name:     name,
+	}
+	namedLoggers[name] = l
+	return l
+}
+
+// SetLoggerLevel sets the level for name and all of its descendants that
+// do not have an explicit level of their own, ie. setting "http" to DEBUG
+// also applies to "http.server" and "http.client".
+func SetLoggerLevel(name string, level int) {
+	namedLock.Lock()
+	defer namedLock.Unlock()
+
+	namedLevels[name] = level
+	for n, l := range namedLoggers {
+		if n == name || strings.HasPrefix(n, name+".") {
+			level, ok := resolveLevel(n)
+			if !ok {
+				level = inheritLevel
+			}
+			atomic.StoreInt32(l.ownLevel, int32(level))
+		}
+	}
+}
+
+// resolveLevel returns the level set for name or its closest ancestor, it
+// must be called with namedLock held
+func resolveLevel(name string) (int, bool) {
+	for {
+		if level, ok := namedLevels[name]; ok {
+			return level, true
+		}
+		idx := strings.LastIndex(name, ".")
+		if idx == -1 {
+			return 0, false
+		}
+		name = name[:idx]
+	}
+}
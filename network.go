@@ -0,0 +1,192 @@
+package simplelog
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	networkQueueSize    = 1024
+	networkDialTimeout  = 5 * time.Second
+	networkWriteTimeout = 5 * time.Second
+	networkMinBackoff   = 100 * time.Millisecond
+	networkMaxBackoff   = 30 * time.Second
+)
+
+var errNetworkQueueFull = errors.New("network queue full, entry dropped")
+
+// NetworkHandler is a Handler that ships entries at or above its level to a
+// remote collector over TCP or UDP, one formatted line per write (or
+// datagram).
+//
+// Entries are queued and written by a background goroutine, so a slow or
+// unreachable collector never blocks logging.  The connection is
+// re-established after errors, backing off exponentially between attempts,
+// and the entry whose write failed is retried once on the new connection.
+// Entries handled while the queue is full, failing that retry or left
+// undelivered when the handler is closed are dropped (see Dropped).
+type NetworkHandler struct {
+	network   string
+	addr      string
+	tlsConfig *tls.Config
+	level     int
+	formatter Formatter
+	queue     chan []byte
+	dropped   uint64
+	stopChan  chan struct{}
+	doneChan  chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewNetworkHandler creates a NetworkHandler writing entries at or above
+// level to addr over network ("tcp" or "udp") using formatter, nil selects
+// the uncolored text format.  When tlsConfig is not nil TCP connections
+// use TLS.
+func NewNetworkHandler(network, addr string, tlsConfig *tls.Config, level int, formatter Formatter) *NetworkHandler {
+	if formatter == nil {
+		formatter = TextFormatter{}
+	}
+	h := &NetworkHandler{
+		network:   network,
+		addr:      addr,
+		tlsConfig: tlsConfig,
+		level:     level,
+		formatter: formatter,
+		queue:     make(chan []byte, networkQueueSize),
+		stopChan:  make(chan struct{}),
+		doneChan:  make(chan struct{}),
+	}
+	go h.run()
+	return h
+}
+
+// Handle implements Handler
+func (h *NetworkHandler) Handle(entry LogEntry) error {
+	if entry.Level < h.level {
+		return nil
+	}
+	select {
+	case <-h.stopChan:
+		return os.ErrClosed
+	default:
+	}
+
+	select {
+	case h.queue <- formatEntry(h.formatter, entry):
+		return nil
+	default:
+		atomic.AddUint64(&h.dropped, 1)
+		return errNetworkQueueFull
+	}
+}
+
+// Dropped returns the number of entries dropped, because the queue was full
+// or they could not be written
+func (h *NetworkHandler) Dropped() uint64 {
+	return atomic.LoadUint64(&h.dropped)
+}
+
+// Close writes any queued entries if connected and closes the connection
+func (h *NetworkHandler) Close() error {
+	h.stopOnce.Do(func() { close(h.stopChan) })
+	<-h.doneChan
+	return nil
+}
+
+func (h *NetworkHandler) run() {
+	var conn net.Conn
+	// line is the entry being written, it is kept across reconnects so a
+	// failed write is retried once on the new connection
+	var line []byte
+	var retried bool
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+		// entries still undelivered once closed are dropped
+		dropped := uint64(len(h.queue))
+		if line != nil {
+			dropped++
+		}
+		atomic.AddUint64(&h.dropped, dropped)
+		close(h.doneChan)
+	}()
+
+	var backoff time.Duration
+	for {
+		if line == nil {
+			select {
+			case line = <-h.queue:
+				retried = false
+			case <-h.stopChan:
+				for conn != nil {
+					select {
+					case line = <-h.queue:
+						if conn = h.send(conn, line); conn != nil {
+							line = nil
+						}
+					default:
+						return
+					}
+				}
+				return
+			}
+		}
+
+		for conn == nil {
+			var err error
+			conn, err = h.dial()
+			if err == nil {
+				backoff = 0
+				break
+			}
+			backoff *= 2
+			if backoff < networkMinBackoff {
+				backoff = networkMinBackoff
+			}
+			if backoff > networkMaxBackoff {
+				backoff = networkMaxBackoff
+			}
+			select {
+			case <-time.After(backoff):
+			case <-h.stopChan:
+				return
+			}
+		}
+
+		switch conn = h.send(conn, line); {
+		case conn != nil:
+			line = nil
+		case retried:
+			// the write failed on a fresh connection too, give up on it
+			atomic.AddUint64(&h.dropped, 1)
+			line = nil
+		default:
+			retried = true
+		}
+	}
+}
+
+// send writes line to conn, returning nil (to reconnect) if that fails
+func (h *NetworkHandler) send(conn net.Conn, line []byte) net.Conn {
+	conn.SetWriteDeadline(time.Now().Add(networkWriteTimeout))
+	if _, err := conn.Write(line); err != nil {
+		conn.Close()
+		return nil
+	}
+	return conn
+}
+
+func (h *NetworkHandler) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: networkDialTimeout}
+	if h.tlsConfig != nil && strings.HasPrefix(h.network, "tcp") {
+		return tls.DialWithDialer(dialer, h.network, h.addr, h.tlsConfig)
+	}
+	return dialer.Dial(h.network, h.addr)
+}
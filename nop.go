@@ -0,0 +1,24 @@
+package simplelog
+
+import (
+	"io"
+	"math"
+)
+
+// disabledLevel is above every level so nothing is logged
+const disabledLevel = math.MaxInt32
+
+// NewNopLogger creates a Logger that drops every message before formatting
+// it, for libraries taking a *Logger dependency and for benchmarks.  Fatal
+// and Panic still exit and panic.
+func NewNopLogger() *Logger {
+	l := NewLogger(disabledLevel)
+	l.SetOutput(io.Discard)
+	return l
+}
+
+// Disable drops every message logged through the default (global) logger
+// until its level is set again with SetLevel
+func Disable() {
+	defaultLogger.Load().SetLevel(disabledLevel)
+}
@@ -0,0 +1,73 @@
+package simplelog
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+type packageLevel struct {
+	path  string
+	level int
+}
+
+// SetPackageLevel overrides the logging level for log calls made from
+// packages whose import path starts with packagePath, ie:
+//
+//	simplelog.SetPackageLevel("myapp/internal/auth", simplelog.DEBUG)
+//
+// enables DEBUG for that package (and its sub-packages) only.
+func (l *Logger) SetPackageLevel(packagePath string, level int) {
+	l.Lock()
+	defer l.Unlock()
+
+	i := sort.Search(len(l.packageLevels), func(i int) bool {
+		return l.packageLevels[i].path >= packagePath
+	})
+	if i < len(l.packageLevels) && l.packageLevels[i].path == packagePath {
+		l.packageLevels[i].level = level
+		return
+	}
+	l.packageLevels = append(l.packageLevels, packageLevel{})
+	copy(l.packageLevels[i+1:], l.packageLevels[i:])
+	l.packageLevels[i] = packageLevel{path: packagePath, level: level}
+	atomic.StoreInt32(&l.hasPackageLevels, 1)
+}
+
+// SetPackageLevel sets a package level override on the default (global) logger
+func SetPackageLevel(packagePath string, level int) {
+	defaultLogger.Load().SetPackageLevel(packagePath, level)
+}
+
+// effectiveLevel must be called with the Logger's lock held
+func (l *Logger) effectiveLevel() int {
+	if len(l.packageLevels) == 0 {
+		return l.getLevel()
+	}
+	pkg := callerPackage()
+	if pkg == "" {
+		return l.getLevel()
+	}
+
+	// overrides are sorted, so the closest override preceding pkg that is
+	// also a prefix of it is the longest (most specific) match
+	i := sort.Search(len(l.packageLevels), func(i int) bool {
+		return l.packageLevels[i].path > pkg
+	})
+	for i--; i >= 0; i-- {
+		if strings.HasPrefix(pkg, l.packageLevels[i].path) {
+			return l.packageLevels[i].level
+		}
+	}
+	return l.getLevel()
+}
+
+// callerPackage returns the import path of the first caller outside of
+// this package
+func callerPackage() string {
+	frame, ok := externalCaller()
+	if !ok {
+		return ""
+	}
+	return packageOf(frame.Function)
+}
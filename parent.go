@@ -0,0 +1,36 @@
+package simplelog
+
+import (
+	"errors"
+)
+
+// ErrCircularChain is returned by SetParent when the new parent would
+// (eventually) forward back to the Logger itself
+var ErrCircularChain = errors.New("circular logger chain")
+
+// SetParent forwards every message emitted by l to parent, which applies its
+// own level and filters.  This allows, for example, a library logger at DEBUG
+// to feed an application logger at INFO.
+func (l *Logger) SetParent(parent *Logger) error {
+	for p := parent; p != nil; {
+		if p.core == l.core {
+			return ErrCircularChain
+		}
+		p.Lock()
+		next := p.parent
+		p.Unlock()
+		p = next
+	}
+
+	l.Lock()
+	l.parent = parent
+	l.Unlock()
+	return nil
+}
+
+// ClearParent stops forwarding messages to the parent logger
+func (l *Logger) ClearParent() {
+	l.Lock()
+	l.parent = nil
+	l.Unlock()
+}
@@ -0,0 +1,97 @@
+package simplelog
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// Plugin is an external log processor that observes the entries emitted by
+// the default (global) logger at the time it was registered.
+//
+// Handle is called from a dedicated goroutine, decoupled from the logging
+// call path, so a slow plugin never blocks logging (entries are dropped when
+// its queue is full).
+type Plugin interface {
+	Name() string
+	Init(l *Logger) error
+	Close() error
+	Handle(entry LogEntry)
+}
+
+const pluginQueueSize = 1024
+
+type pluginRunner struct {
+	plugin      Plugin
+	unsubscribe func()
+	entryChan   chan LogEntry
+	doneChan    chan struct{}
+}
+
+var plugins = make(map[string]*pluginRunner)
+var pluginsLock sync.Mutex
+
+// RegisterPlugin initializes p with the default (global) logger and starts
+// feeding it log entries
+func RegisterPlugin(p Plugin) error {
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+
+	name := p.Name()
+	if _, ok := plugins[name]; ok {
+		return errors.New("plugin already registered")
+	}
+	l := DefaultLogger()
+	if err := p.Init(l); err != nil {
+		return err
+	}
+
+	r := &pluginRunner{
+		plugin:    p,
+		entryChan: make(chan LogEntry, pluginQueueSize),
+		doneChan:  make(chan struct{}),
+	}
+	r.unsubscribe = l.Subscribe(r.entryChan)
+	go r.run()
+
+	plugins[name] = r
+	return nil
+}
+
+// UnregisterPlugin stops feeding entries to the named plugin, waits for it
+// to handle those already queued, and closes it
+func UnregisterPlugin(name string) error {
+	pluginsLock.Lock()
+	r, ok := plugins[name]
+	delete(plugins, name)
+	pluginsLock.Unlock()
+
+	if !ok {
+		return errors.New("plugin not registered")
+	}
+
+	r.unsubscribe()
+	close(r.entryChan)
+	<-r.doneChan
+	return r.plugin.Close()
+}
+
+// PluginNames returns the sorted names of all registered plugins
+func PluginNames() []string {
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *pluginRunner) run() {
+	for entry := range r.entryChan {
+		r.plugin.Handle(entry)
+	}
+	close(r.doneChan)
+}
@@ -0,0 +1,84 @@
+package simplelog
+
+import (
+	"strings"
+)
+
+// lnFormat returns a format string that joins n operands with spaces, like
+// fmt.Sprintln, so that untrusted strings containing % are printed verbatim
+func lnFormat(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strings.Repeat("%v ", n-1) + "%v"
+}
+
+// Logln logs args, separated by spaces, at level without treating any of
+// them as a format string
+func (l *Logger) Logln(level int, args ...interface{}) {
+	if !l.maybeEnabled(level) {
+		return
+	}
+	l.Log(level, lnFormat(len(args)), args...)
+}
+
+// Traceln logs args, separated by spaces, as a TRACE message
+func (l *Logger) Traceln(args ...interface{}) {
+	l.Logln(TRACE, args...)
+}
+
+// Debugln logs args, separated by spaces, as a DEBUG message
+func (l *Logger) Debugln(args ...interface{}) {
+	l.Logln(DEBUG, args...)
+}
+
+// Infoln logs args, separated by spaces, as an INFO message
+func (l *Logger) Infoln(args ...interface{}) {
+	l.Logln(INFO, args...)
+}
+
+// Warningln logs args, separated by spaces, as a WARNING message
+func (l *Logger) Warningln(args ...interface{}) {
+	l.Logln(WARNING, args...)
+}
+
+// Errorln logs args, separated by spaces, as an ERROR message
+func (l *Logger) Errorln(args ...interface{}) {
+	l.Logln(ERROR, args...)
+}
+
+// Logln is a convenience method to log args, separated by spaces, on the
+// default (global) logger for any level
+func Logln(level int, args ...interface{}) {
+	defaultLogger.Load().Logln(level, args...)
+}
+
+// Traceln is a convenience method to log args as a TRACE message on the
+// default (global) logger
+func Traceln(args ...interface{}) {
+	defaultLogger.Load().Logln(TRACE, args...)
+}
+
+// Debugln is a convenience method to log args as a DEBUG message on the
+// default (global) logger
+func Debugln(args ...interface{}) {
+	defaultLogger.Load().Logln(DEBUG, args...)
+}
+
+// Infoln is a convenience method to log args as an INFO message on the
+// default (global) logger
+func Infoln(args ...interface{}) {
+	defaultLogger.Load().Logln(INFO, args...)
+}
+
+// Warningln is a convenience method to log args as a WARNING message on the
+// default (global) logger
+func Warningln(args ...interface{}) {
+	defaultLogger.Load().Logln(WARNING, args...)
+}
+
+// Errorln is a convenience method to log args as an ERROR message on the
+// default (global) logger
+func Errorln(args ...interface{}) {
+	defaultLogger.Load().Logln(ERROR, args...)
+}
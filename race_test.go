@@ -0,0 +1,5 @@
+//go:build race
+
+package simplelog
+
+const raceEnabled = true
@@ -0,0 +1,113 @@
+package simplelog
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// RotatingFileWriter is an io.WriteCloser that writes to a file, rotating
+// it when it would grow beyond maxBytes.  Rotated files are renamed
+// path.1, path.2, ... up to maxBackups, the oldest being removed.
+type RotatingFileWriter struct {
+	sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+// NewRotatingFileWriter opens (or creates) path for appending and returns a
+// RotatingFileWriter writing to it
+func NewRotatingFileWriter(path string, maxBytes int64, maxBackups int) (*RotatingFileWriter, error) {
+	w := &RotatingFileWriter{
+		path:       path,
+		maxBytes:   maxBytes,
+		maxBackups: maxBackups,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes p to the current file, rotating first if p would make the
+// file exceed maxBytes
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.f == nil {
+		return 0, os.ErrClosed
+	}
+	if w.size > 0 && w.size+int64(len(p)) > w.maxBytes {
+		// when rotation fails the current file is reopened and written to,
+		// rotation is retried on the next write
+		if err := w.rotate(); err != nil && w.f == nil {
+			return 0, err
+		}
+	}
+	n, err := w.f.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file
+func (w *RotatingFileWriter) Close() error {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.f == nil {
+		return nil
+	}
+	err := w.f.Close()
+	w.f = nil
+	return err
+}
+
+func (w *RotatingFileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.f = f
+	w.size = fi.Size()
+	return nil
+}
+
+// rotate renames the current file out of the way and opens a new one, if
+// that fails w.path is reopened for appending
+func (w *RotatingFileWriter) rotate() error {
+	err := w.f.Close()
+	w.f = nil
+	if err == nil {
+		err = w.shift()
+	}
+	if oerr := w.open(); err == nil {
+		err = oerr
+	}
+	return err
+}
+
+// shift renames path to path.1, path.1 to path.2 and so on, removing the
+// oldest backup
+func (w *RotatingFileWriter) shift() error {
+	if w.maxBackups <= 0 {
+		return os.Remove(w.path)
+	}
+	os.Remove(w.backupName(w.maxBackups))
+	for i := w.maxBackups - 1; i > 0; i-- {
+		os.Rename(w.backupName(i), w.backupName(i+1))
+	}
+	return os.Rename(w.path, w.backupName(1))
+}
+
+func (w *RotatingFileWriter) backupName(n int) string {
+	return fmt.Sprintf("%s.%d", w.path, n)
+}
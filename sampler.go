@@ -0,0 +1,114 @@
+package simplelog
+
+import (
+	"time"
+)
+
+type sampleKey struct {
+	level     int
+	prefix    string
+	msg       string
+	fieldsKey string
+}
+
+type sampleCount struct {
+	start  time.Time
+	count  int
+	fields Fields
+}
+
+type sampleSummary struct {
+	sampleKey
+	fields     Fields
+	suppressed int
+}
+
+type sampler struct {
+	n         int
+	per       time.Duration
+	counts    map[sampleKey]*sampleCount
+	lastSweep time.Time
+}
+
+// SetSampler limits identical messages (same level, prefix, text and fields)
+// to n per interval, the extras are suppressed and once the interval has
+// elapsed a single "message repeated X times" summary is logged.  Pending
+// summaries are also logged by Flush and Close.  An n of 0 disables sampling.
+func (l *Logger) SetSampler(n int, per time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	if n <= 0 {
+		l.sampler = nil
+		return
+	}
+	l.sampler = &sampler{
+		n:         n,
+		per:       per,
+		counts:    make(map[sampleKey]*sampleCount),
+		lastSweep: time.Now(),
+	}
+}
+
+// sample returns whether a message should be emitted along with summaries
+// for messages whose interval has elapsed with suppressed repeats
+func (s *sampler) sample(level int, prefix string, msg string, fields Fields, now time.Time) (bool, []sampleSummary) {
+	var summaries []sampleSummary
+
+	if now.Sub(s.lastSweep) >= s.per {
+		for key, c := range s.counts {
+			if now.Sub(c.start) < s.per {
+				continue
+			}
+			if c.count > s.n {
+				summaries = append(summaries, sampleSummary{key, c.fields, c.count - s.n})
+			}
+			delete(s.counts, key)
+		}
+		s.lastSweep = now
+	}
+
+	key := sampleKey{level, prefix, msg, formatFields(fields)}
+	c, ok := s.counts[key]
+	if !ok || now.Sub(c.start) >= s.per {
+		if ok && c.count > s.n {
+			summaries = append(summaries, sampleSummary{key, c.fields, c.count - s.n})
+		}
+		s.counts[key] = &sampleCount{start: now, count: 1, fields: fields}
+		return true, summaries
+	}
+	c.count++
+	return c.count <= s.n, summaries
+}
+
+// flush returns summaries for all messages with suppressed repeats and
+// restarts their intervals
+func (s *sampler) flush() []sampleSummary {
+	var summaries []sampleSummary
+	for key, c := range s.counts {
+		if c.count > s.n {
+			summaries = append(summaries, sampleSummary{key, c.fields, c.count - s.n})
+			delete(s.counts, key)
+		}
+	}
+	return summaries
+}
+
+// flushSampler logs any pending sampler summaries, it must be called with
+// the Logger's lock held
+func (l *Logger) flushSampler() {
+	if l.sampler == nil {
+		return
+	}
+	l.logSampleSummaries(l.sampler.flush())
+}
+
+// logSampleSummaries must be called with the Logger's lock held, messages
+// are sampled without their prefix and fields so each summary is logged with
+// the prefix and fields of the messages it summarizes
+func (l *Logger) logSampleSummaries(summaries []sampleSummary) {
+	for _, sum := range summaries {
+		sl := &Logger{core: l.core, fields: sum.fields, prefix: sum.prefix, ownLevel: l.ownLevel, inherits: l.inherits}
+		sl.log(sum.level, "message repeated %d times: %s", []interface{}{sum.suppressed, sum.msg})
+	}
+}
@@ -0,0 +1,92 @@
+package simplelog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Section buffers log messages so that they can be emitted together under
+// a title, or discarded if nothing interesting happened.
+type Section struct {
+	sync.Mutex
+	l       *Logger
+	title   string
+	entries []LogEntry
+}
+
+// Section creates a new Section titled title that emits to l
+func (l *Logger) Section(title string) *Section {
+	return &Section{l: l, title: title}
+}
+
+// Log formats and buffers a message until the Section is flushed
+func (s *Section) Log(level int, msg string, args ...interface{}) {
+	s.Lock()
+	s.entries = append(s.entries, LogEntry{Level: level, Time: time.Now(), Message: fmt.Sprintf(msg, args...)})
+	s.Unlock()
+}
+
+// Flush emits the buffered messages between a "=== title ===" header and a
+// "=== end title ===" footer, logged at the highest buffered level.  The
+// whole section is written at once, so it is not interleaved with messages
+// from other goroutines, and each message keeps the time it was logged at.
+func (s *Section) Flush() {
+	s.flush(func([]LogEntry) bool { return true })
+}
+
+// FlushIfAny flushes the Section only if at least one of the buffered
+// messages is at or above level, otherwise the buffer is discarded
+func (s *Section) FlushIfAny(level int) {
+	s.flush(func(entries []LogEntry) bool {
+		for _, e := range entries {
+			if e.Level >= level {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (s *Section) flush(emit func([]LogEntry) bool) {
+	s.Lock()
+	entries := s.entries
+	s.entries = nil
+	s.Unlock()
+
+	if len(entries) == 0 || !emit(entries) {
+		return
+	}
+
+	maxLevel := entries[0].Level
+	for _, e := range entries {
+		if e.Level > maxLevel {
+			maxLevel = e.Level
+		}
+	}
+
+	l := s.l
+	var forward []LogEntry
+	logAt := func(level int, dt time.Time, msg string) {
+		emitted, watermark := l.logAt(level, dt, "%s", []interface{}{msg})
+		if watermark > 0 {
+			l.logWatermark(level, watermark)
+		}
+		if emitted && l.parent != nil {
+			forward = append(forward, LogEntry{Level: level, Message: msg})
+		}
+	}
+
+	l.Lock()
+	logAt(maxLevel, entries[0].Time, "=== "+s.title+" ===")
+	for _, e := range entries {
+		logAt(e.Level, e.Time, e.Message)
+	}
+	logAt(maxLevel, time.Now(), "=== end "+s.title+" ===")
+	parent := l.parent
+	l.Unlock()
+
+	for _, e := range forward {
+		parent.With(l.fields).Log(e.Level, "%s", e.Message)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package simplelog
+
+// EnableSignalLevelToggle is a no-op on platforms without SIGUSR1 and
+// SIGUSR2
+func EnableSignalLevelToggle() func() {
+	return func() {}
+}
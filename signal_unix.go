@@ -0,0 +1,54 @@
+//go:build unix
+
+package simplelog
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// EnableSignalLevelToggle switches the default (global) logger to DEBUG
+// when the process receives SIGUSR1 and back to its previous level on
+// SIGUSR2, so debug logging can be turned on in a running daemon without
+// restarting it.
+//
+// The returned function stops watching for the signals.
+func EnableSignalLevelToggle() func() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGUSR1, syscall.SIGUSR2)
+	exitChan := make(chan struct{})
+
+	go func() {
+		var restore int
+		debugging := false
+		for {
+			select {
+			case sig := <-sigChan:
+				l := defaultLogger.Load()
+				switch sig {
+				case syscall.SIGUSR1:
+					if !debugging {
+						restore = l.getLevel()
+						debugging = true
+					}
+					l.SetLevel(DEBUG)
+				case syscall.SIGUSR2:
+					if debugging {
+						l.SetLevel(restore)
+						debugging = false
+					}
+				}
+			case <-exitChan:
+				signal.Stop(sigChan)
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(exitChan) })
+	}
+}
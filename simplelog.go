@@ -5,22 +5,29 @@
 package simplelog
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
-	"runtime"
+	"runtime/debug"
+	"runtime/trace"
 	"sync"
-	"syscall"
+	"sync/atomic"
 	"strings"
 	"time"
-	"unsafe"
 )
 
+// The levels are untyped constants so they can be used both as a Level
+// and with the int based API.
 const (
-	DEBUG = iota
+	TRACE = iota - 1
+	DEBUG
 	INFO
 	WARNING
 	ERROR
+	FATAL
+	PANIC
 )
 
 const (
@@ -28,28 +35,143 @@ const (
 	green  = "\033[0;32;49m"
 	yellow = "\033[0;33;49m"
 	blue   = "\033[0;34;49m"
+	cyan   = "\033[0;36;49m"
 	reset  = "\033[0m"
 )
 
-var defaultLogger *Logger
-var istty bool
+var defaultLogger atomic.Pointer[Logger]
+var unicodeLocale bool
+
+var builtinLevels = map[string]int{
+	"trace":   TRACE,
+	"debug":   DEBUG,
+	"info":    INFO,
+	"warning": WARNING,
+	"error":   ERROR,
+	"fatal":   FATAL,
+	"panic":   PANIC,
+}
+
+var levelAliases = make(map[string]int)
+var levelAliasesLock sync.RWMutex
 
 func init() {
-	defaultLogger = &Logger{level: INFO}
-	istty = isatty(os.Stderr)
+	unicodeLocale = isUnicodeLocale()
+	defaultLogger.Store(NewLogger(INFO))
 }
 
 // Logger is the basic type if you want to maintain multiple instances
 // with a different loggin level.  In most cases just use the public (global)
 // functions.
+//
+// Loggers derived with With or Named share their configuration and output
+// with the Logger they were derived from.
 type Logger struct {
+	*core
+	fields Fields
+	prefix string
+
+	// for child Loggers (see Named and GetLogger) ownLevel is the child's
+	// own level, or inheritLevel to follow the Logger it inherits from (the
+	// default logger when inherits is nil)
+	ownLevel *int32
+	inherits *Logger
+
+	// name is set for Loggers returned by GetLogger
+	name string
+}
+
+// core is the configuration and output state shared by a Logger and all
+// Loggers derived from it
+type core struct {
 	sync.Mutex
-	level int
+	level     int32
+	out       io.Writer
+	istty     bool
+	colorMode ColorMode
+
+	outputLevel int
+
+	stackTraceOnWrappedError bool
+	subscribers              []*subscriber
+	decorators               map[int]string
+	emojiLevels              bool
+	levelEmoji               map[int]string
+	packageLevels            []packageLevel
+	hasPackageLevels         int32
+	diffMode                 bool
+	prevDebugMsg             string
+	hasPrevDebugMsg          bool
+	lineNumbers              bool
+	lineNumber               uint64
+	volume                   map[int]uint64
+	watermarks               map[int][]*watermark
+	writeTimeout             time.Duration
+	writeTimeouts            uint64
+	parent                   *Logger
+	traceAnnotation          bool
+	formatter                Formatter
+	reportCaller             bool
+	handlers                 []Handler
+	async                    *asyncWriter
+	dropped                  uint64
+	timeFormat               string
+	utc                      bool
+	sampler                  *sampler
+	hooks                    []Hook
+	exitHandlers             []func()
+	exitFunc                 func(code int)
+	stackTraceLevel          int
+	stackTraces              bool
+	colorScheme              map[int]string
+	dedup                    *deduper
+	errOut                   io.Writer
+	errIstty                 bool
+	errLevel                 int
+}
+
+// LogEntry describes a single emitted log message
+type LogEntry struct {
+	Level   int
+	Time    time.Time
+	Message string
+	Fields  Fields
+	Caller  string
 }
 
 // NewLogger creates a new Logger instance with the specified initial log level
+// writing to os.Stderr
 func NewLogger(level int) *Logger {
-	return &Logger{level: level}
+	return &Logger{
+		core: &core{
+			level:       int32(level),
+			out:         os.Stderr,
+			istty:       isatty(os.Stderr),
+			outputLevel: TRACE,
+		},
+	}
+}
+
+// SetOutput sets the destination for log messages.  Colors are enabled
+// only when w is an *os.File attached to a terminal, or a MultiWriter
+// including one.
+func (l *Logger) SetOutput(w io.Writer) {
+	istty := writerIsTTY(w)
+
+	l.Lock()
+	l.out = w
+	l.istty = istty
+	l.Unlock()
+}
+
+func writerIsTTY(w io.Writer) bool {
+	switch w := w.(type) {
+	case *os.File:
+		return isatty(w)
+	case *multiWriter:
+		return w.hasTTY()
+	}
+	return false
 }
 
 // SetLevel takes either a string of int specifying the the new logging level
@@ -62,93 +184,522 @@ func NewLogger(level int) *Logger {
 //
 // Valid levels (string = int):
 //
+//     TRACE   = -1
 //     DEBUG   = 0
 //     INFO    = 1
 //     WARNING = 2
 //     ERROR   = 3
+//     FATAL   = 4
+//     PANIC   = 5
+//
+// SetLevel is safe to call concurrently with logging, ie. from a signal
+// handler at runtime.
 func (l *Logger) SetLevel(lvl interface{}) error {
+	var level int
 	switch lvl.(type) {
 	case int:
-		l.level = lvl.(int)
+		level = lvl.(int)
+	case Level:
+		level = int(lvl.(Level))
 	case string:
-		switch strings.ToLower(lvl.(string)) {
-		case "debug":
-			l.level = DEBUG
-		case "info":
-			l.level = INFO
-		case "warning":
-			l.level = WARNING
-		case "error":
-			l.level = ERROR
-		default:
+		var ok bool
+		level, ok = lookupLevel(strings.ToLower(lvl.(string)))
+		if !ok {
 			return errors.New("invalid level")
 		}
 	default:
 		return errors.New("invalid level")
 	}
+	if l.name != "" {
+		SetLoggerLevel(l.name, level)
+		return nil
+	}
+	if l.ownLevel != nil {
+		atomic.StoreInt32(l.ownLevel, int32(level))
+		return nil
+	}
+	l.setLevel(level)
 	return nil
 }
 
+func (l *Logger) getLevel() int {
+	followedDefault := false
+	for l.ownLevel != nil {
+		if level := atomic.LoadInt32(l.ownLevel); level != inheritLevel {
+			return int(level)
+		}
+		if l.inherits != nil {
+			l = l.inherits
+			continue
+		}
+		if followedDefault {
+			// the default logger inherits from a GetLogger logger,
+			// fall back to the shared level to avoid looping
+			break
+		}
+		// Loggers from GetLogger follow the current default logger
+		followedDefault = true
+		l = defaultLogger.Load()
+	}
+	return int(atomic.LoadInt32(&l.level))
+}
+
+func (l *Logger) setLevel(level int) {
+	atomic.StoreInt32(&l.level, int32(level))
+}
+
+// RegisterLevelAlias makes alias resolve to level when passed as a string
+// to SetLevel, ie. RegisterLevelAlias("verbose", DEBUG).  Aliases are case
+// insensitive and the built-in level names cannot be redefined.
+func RegisterLevelAlias(alias string, level int) error {
+	alias = strings.ToLower(alias)
+	if _, ok := builtinLevels[alias]; ok {
+		return errors.New("cannot redefine built-in level")
+	}
+	if level < TRACE || level > PANIC {
+		return errors.New("invalid level")
+	}
+	levelAliasesLock.Lock()
+	levelAliases[alias] = level
+	levelAliasesLock.Unlock()
+	return nil
+}
+
+// UnregisterLevelAlias removes an alias added with RegisterLevelAlias,
+// built-in level names are left untouched
+func UnregisterLevelAlias(alias string) {
+	levelAliasesLock.Lock()
+	delete(levelAliases, strings.ToLower(alias))
+	levelAliasesLock.Unlock()
+}
+
+func lookupLevel(name string) (int, bool) {
+	if level, ok := builtinLevels[name]; ok {
+		return level, true
+	}
+	levelAliasesLock.RLock()
+	level, ok := levelAliases[name]
+	levelAliasesLock.RUnlock()
+	return level, ok
+}
+
+// SetOutputLevel sets the minimum level written to the Logger's output,
+// independently of the Logger's level which applies to all destinations.
+// For example, to write DEBUG and above to a file but only WARNING and
+// above to os.Stderr:
+//
+//	l.SetLevel(simplelog.DEBUG)
+//	l.SetOutputLevel(simplelog.WARNING)
+//	l.AddHandler(simplelog.NewWriterHandler(f, simplelog.DEBUG, nil))
+func (l *Logger) SetOutputLevel(level int) {
+	l.Lock()
+	l.outputLevel = level
+	l.Unlock()
+}
+
+// SetPrefix tags every message logged by this Logger with a component name,
+// rendered between the timestamp and the message, ie:
+//
+//	[INFO 2013-03-31 12:00:00.000000] [nsqd] message
+//
+// Unlike other settings the prefix is not shared with the Logger l was
+// derived from.
+func (l *Logger) SetPrefix(prefix string) {
+	l.Lock()
+	l.prefix = prefix
+	l.Unlock()
+}
+
+// SetStackTraceOnWrappedError toggles automatically including a stack trace
+// for ERROR messages whose arguments contain a wrapped error (one implementing
+// Unwrap() error).  Plain errors are logged normally.
+func (l *Logger) SetStackTraceOnWrappedError(enabled bool) {
+	l.Lock()
+	l.stackTraceOnWrappedError = enabled
+	l.Unlock()
+}
+
+// SetLineNumbers toggles prefixing each emitted line with a monotonically
+// increasing line number
+func (l *Logger) SetLineNumbers(enabled bool) {
+	l.Lock()
+	l.lineNumbers = enabled
+	l.Unlock()
+}
+
+// ResetLineNumbers restarts line numbering from 1
+func (l *Logger) ResetLineNumbers() {
+	atomic.StoreUint64(&l.lineNumber, 0)
+}
+
+// SetWriteTimeout sets the maximum time to wait for a message to be written,
+// messages that take longer are dropped (the write is abandoned) and counted
+// in WriteTimeouts.  A zero timeout (the default) waits indefinitely.
+func (l *Logger) SetWriteTimeout(d time.Duration) {
+	l.Lock()
+	l.writeTimeout = d
+	l.Unlock()
+}
+
+// WriteTimeouts returns the number of writes abandoned due to the write timeout
+func (l *Logger) WriteTimeouts() uint64 {
+	return atomic.LoadUint64(&l.writeTimeouts)
+}
+
+// EnableTraceAnnotation toggles annotating the current goroutine's runtime
+// trace (see runtime/trace) with every emitted message, so that log output
+// can be correlated with scheduling events in `go tool trace`
+func (l *Logger) EnableTraceAnnotation(enabled bool) {
+	l.Lock()
+	l.traceAnnotation = enabled
+	l.Unlock()
+}
+
+// Trace logs a TRACE message
+func (l *Logger) Trace(s string, args ...interface{}) {
+	l.Log(TRACE, s, args...)
+}
+
+// Debug logs a DEBUG message
+func (l *Logger) Debug(s string, args ...interface{}) {
+	l.Log(DEBUG, s, args...)
+}
+
+// Info logs an INFO message
+func (l *Logger) Info(s string, args ...interface{}) {
+	l.Log(INFO, s, args...)
+}
+
+// Warning logs a WARNING message
+func (l *Logger) Warning(s string, args ...interface{}) {
+	l.Log(WARNING, s, args...)
+}
+
+// Error logs an ERROR message
+func (l *Logger) Error(s string, args ...interface{}) {
+	l.Log(ERROR, s, args...)
+}
+
+// Fatal logs a FATAL message, flushes the output, runs the registered exit
+// handlers and calls os.Exit(1)
+func (l *Logger) Fatal(s string, args ...interface{}) {
+	l.Log(FATAL, s, args...)
+	l.Flush()
+	l.exit(1)
+}
+
+// Fatalf is equivalent to Fatal, provided for compatibility with the
+// standard library's log package
+func (l *Logger) Fatalf(s string, args ...interface{}) {
+	l.Fatal(s, args...)
+}
+
+// Panic logs a PANIC message, flushes the Logger and then panics with the
+// formatted message
+func (l *Logger) Panic(s string, args ...interface{}) {
+	l.Log(PANIC, s, args...)
+	l.Flush()
+	panic(fmt.Sprintf(s, args...))
+}
+
 // Log formats the message with the supplied arguments to fmt.Sprintf, applies
-// color based on log level, and prints to os.Stderr
+// color based on log level, and writes to the Logger's output (os.Stderr by
+// default)
 func (l *Logger) Log(level int, s string, args ...interface{}) {
+	if !l.maybeEnabled(level) {
+		return
+	}
+
 	l.Lock()
-	defer l.Unlock()
+	emitted, watermark := l.log(level, s, args)
+	if watermark > 0 {
+		l.logWatermark(level, watermark)
+	}
+	parent := l.parent
+	l.Unlock()
 
-	if level < l.level {
-		return
+	if emitted && parent != nil {
+		parent.With(l.fields).Log(level, s, args...)
+	}
+}
+
+// log must be called with the Logger's lock held, it returns whether the
+// message was emitted and the volume watermark it reached (if any)
+func (l *Logger) log(level int, s string, args []interface{}) (bool, uint64) {
+	return l.logAt(level, time.Now(), s, args)
+}
+
+// logAt is like log but timestamps the message with dt
+func (l *Logger) logAt(level int, dt time.Time, s string, args []interface{}) (bool, uint64) {
+	if level < l.effectiveLevel() {
+		return false, 0
 	}
 	
+	if l.utc {
+		dt = dt.UTC()
+	}
+	logMsg := fmt.Sprintf(s, args...)
+
+	if l.sampler != nil {
+		emit, summaries := l.sampler.sample(level, l.prefix, logMsg, l.fields, dt)
+		l.logSampleSummaries(summaries)
+		if !emit {
+			return false, 0
+		}
+	}
+
+	if l.prefix != "" {
+		logMsg = "[" + l.prefix + "] " + logMsg
+	}
+	if l.wantsStackTrace(level, args) {
+		logMsg += "\n" + string(debug.Stack())
+	}
+
+	if l.dedup != nil && !l.dedupe(level, logMsg, dt) {
+		return false, 0
+	}
+
+	var caller string
+	if l.reportCaller {
+		caller = callerLocation()
+	}
+
+	if level >= l.outputLevel {
+		buf := getBuffer()
+		line := l.appendLine((*buf)[:0], level, dt, logMsg, caller)
+		out, _ := l.outputFor(level)
+		if err := l.write(out, line); err == nil {
+			atomic.AddUint64(&l.lineNumber, 1)
+		}
+		// asynchronous and timed out writes may still reference line
+		if l.async == nil && l.writeTimeout <= 0 {
+			*buf = line
+			putBuffer(buf)
+		}
+	}
+
+	if l.traceAnnotation && trace.IsEnabled() {
+		_, levelName := parseLevel(level)
+		trace.Log(context.Background(), levelName, logMsg)
+	}
+
+	entry := LogEntry{Level: level, Time: dt, Message: logMsg, Fields: l.fields, Caller: caller}
+	for _, h := range l.handlers {
+		h.Handle(entry)
+	}
+	l.fireHooks(entry)
+	l.publish(entry)
+
+	return true, l.countVolume(level)
+}
+
+// appendText appends the Tornado style line to b, it must be called with
+// the Logger's lock held
+func (l *Logger) appendText(b []byte, level int, dt time.Time, logMsg string, caller string) []byte {
 	postfix := reset
-	prefix, levelTxt := parseLevel(level)
-	if !istty {
+	prefix := l.levelColor(level)
+	_, levelTxt := parseLevel(level)
+	decorators := l.decorators[level]
+	if !l.colored(level) {
 		prefix = ""
 		postfix = ""
+		decorators = ""
+	} else if l.emojiLevels && unicodeLocale {
+		if emoji, ok := l.levelEmojiFor(level); ok {
+			levelTxt = emoji
+		}
 	}
 
-	dt := time.Now()
-	year, month, day := dt.Date()
-	hour, minute, second := dt.Clock()
-	dateTime := fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d.%06d", year, month, day,
-		hour, minute, second,
-		dt.Nanosecond()/1e3)
+	outMsg := logMsg
+	if l.diffMode {
+		if level == DEBUG {
+			if l.hasPrevDebugMsg && l.colored(level) {
+				outMsg = diffWords(l.prevDebugMsg, logMsg, blue)
+			}
+			l.prevDebugMsg = logMsg
+			l.hasPrevDebugMsg = true
+		} else {
+			l.prevDebugMsg = ""
+			l.hasPrevDebugMsg = false
+		}
+	}
 
-	logMsg := fmt.Sprintf(s, args...)
-	fmt.Fprintf(os.Stderr, "%s[%s %s]%s %s\n", prefix, levelTxt, dateTime, postfix, logMsg)
+	if l.lineNumbers {
+		b = appendPadded(b, atomic.LoadUint64(&l.lineNumber)+1, 6, ' ')
+		b = append(b, ' ')
+	}
+	b = append(b, prefix...)
+	b = append(b, decorators...)
+	b = append(b, '[')
+	b = append(b, levelTxt...)
+	b = append(b, ' ')
+	if l.timeFormat != "" {
+		b = dt.AppendFormat(b, l.timeFormat)
+	} else {
+		b = appendTimestamp(b, dt)
+	}
+	if caller != "" {
+		b = append(b, ' ')
+		b = append(b, caller...)
+	}
+	b = append(b, ']')
+	b = append(b, postfix...)
+	b = append(b, ' ')
+	b = append(b, outMsg...)
+	b = appendFields(b, l.fields)
+	return append(b, '\n')
+}
+
+// write must be called with the Logger's lock held
+func (l *Logger) write(out io.Writer, p []byte) error {
+	if l.async != nil {
+		return l.enqueue(out, p)
+	}
+	if l.writeTimeout <= 0 {
+		_, err := out.Write(p)
+		return err
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := out.Write(p)
+		errChan <- err
+	}()
+
+	timer := time.NewTimer(l.writeTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-errChan:
+		return err
+	case <-timer.C:
+		atomic.AddUint64(&l.writeTimeouts, 1)
+		return errors.New("write timed out")
+	}
+}
+
+// flushOutput flushes (or syncs) the outputs if they support it, it must be
+// called with the Logger's lock held
+func (l *Logger) flushOutput() error {
+	err := flushWriter(l.out)
+	if l.errOut != nil {
+		if ferr := flushWriter(l.errOut); err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
+
+// flushWriter flushes or syncs w when it supports it
+func flushWriter(w io.Writer) error {
+	switch out := w.(type) {
+	case interface{ Flush() error }:
+		return out.Flush()
+	case interface{ Sync() error }:
+		if w == os.Stdout || w == os.Stderr {
+			// syncing a terminal or pipe fails and is unnecessary
+			return nil
+		}
+		return out.Sync()
+	}
+	return nil
+}
+
+// Default returns the default (global) logger used by the package level
+// functions
+func Default() *Logger {
+	return defaultLogger.Load()
+}
+
+// SetDefault replaces the default (global) logger with l, so that every
+// package level function, including Log, With and the output and level
+// setters, routes through it
+func SetDefault(l *Logger) {
+	defaultLogger.Store(l)
+}
+
+// DefaultLogger is an alias for Default
+func DefaultLogger() *Logger {
+	return Default()
+}
+
+// SetDefaultLogger is an alias for SetDefault
+func SetDefaultLogger(l *Logger) {
+	SetDefault(l)
 }
 
 // SetLevel sets the logging level for the default (global) logger
-func SetLevel(lvl interface{}) {
-	defaultLogger.SetLevel(lvl)
+func SetLevel(lvl interface{}) error {
+	return defaultLogger.Load().SetLevel(lvl)
+}
+
+// MustSetLevel is like SetLevel but panics if lvl is invalid
+func MustSetLevel(lvl interface{}) {
+	if err := SetLevel(lvl); err != nil {
+		panic(fmt.Sprintf("simplelog: %s %v", err, lvl))
+	}
+}
+
+// SetPrefix sets the component prefix of the default (global) logger
+func SetPrefix(prefix string) {
+	defaultLogger.Load().SetPrefix(prefix)
+}
+
+// SetOutput sets the destination for the default (global) logger
+func SetOutput(w io.Writer) {
+	defaultLogger.Load().SetOutput(w)
+}
+
+// Trace is a convenience method to log a TRACE message on the default (global) logger
+func Trace(s string, args ...interface{}) {
+	defaultLogger.Load().Log(TRACE, s, args...)
 }
 
 // Debug is a convenience method to log a DEBUG message on the default (global) logger
 func Debug(s string, args ...interface{}) {
-	defaultLogger.Log(DEBUG, s, args...)
+	defaultLogger.Load().Log(DEBUG, s, args...)
 }
 
 // Info is a convenience method to log an INFO message on the default (global) logger
 func Info(s string, args ...interface{}) {
-	defaultLogger.Log(INFO, s, args...)
+	defaultLogger.Load().Log(INFO, s, args...)
 }
 
 // Warning is a convenience method to log a WARNING message on the default (global) logger
 func Warning(s string, args ...interface{}) {
-	defaultLogger.Log(WARNING, s, args...)
+	defaultLogger.Load().Log(WARNING, s, args...)
 }
 
 // Error is a convenience method to log an ERROR message on the default (global) logger
 func Error(s string, args ...interface{}) {
-	defaultLogger.Log(ERROR, s, args...)
+	defaultLogger.Load().Log(ERROR, s, args...)
+}
+
+// Fatal is a convenience method to log a FATAL message on the default (global) logger
+// and then call os.Exit(1)
+func Fatal(s string, args ...interface{}) {
+	defaultLogger.Load().Fatal(s, args...)
+}
+
+// Fatalf is equivalent to Fatal
+func Fatalf(s string, args ...interface{}) {
+	defaultLogger.Load().Fatal(s, args...)
+}
+
+// Panic is a convenience method to log a PANIC message on the default (global) logger
+// and then panic
+func Panic(s string, args ...interface{}) {
+	defaultLogger.Load().Panic(s, args...)
 }
 
 // Log is a convenience method to log a message on the default (global) logger for any level
 func Log(level int, s string, args ...interface{}) {
-	defaultLogger.Log(level, s, args...)
+	defaultLogger.Load().Log(level, s, args...)
 }
 
 func parseLevel(level int) (string, string) {
 	switch level {
+	case TRACE:
+		return cyan, "TRACE"
 	case DEBUG:
 		return blue, "DEBUG"
 	case INFO:
@@ -157,23 +708,22 @@ func parseLevel(level int) (string, string) {
 		return yellow, "WARNING"
 	case ERROR:
 		return red, "ERROR"
+	case FATAL:
+		return red, "FATAL"
+	case PANIC:
+		return red, "PANIC"
 	}
 	return green, "INFO"
 }
 
-func ioctl(fd, request, argp uintptr) syscall.Errno {
-	_, _, errorp := syscall.Syscall(syscall.SYS_IOCTL, fd, request, argp)
-	return errorp
-}
-
-func isatty(f *os.File) bool {
-	switch runtime.GOOS {
-	case "darwin":
-	case "linux":
-	default:
-		return false
+func hasWrappedError(args []interface{}) bool {
+	for _, arg := range args {
+		if _, ok := arg.(interface {
+			error
+			Unwrap() error
+		}); ok {
+			return true
+		}
 	}
-	var t [2]byte
-	errno := ioctl(f.Fd(), syscall.TIOCGPGRP, uintptr(unsafe.Pointer(&t)))
-	return errno == 0
+	return false
 }
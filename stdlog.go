@@ -0,0 +1,37 @@
+package simplelog
+
+import (
+	"fmt"
+	"log"
+)
+
+// StdLogger returns a standard library *log.Logger that logs each message
+// through l at level, for libraries that require one (ie.
+// http.Server.ErrorLog)
+func (l *Logger) StdLogger(level int) *log.Logger {
+	return log.New(l.Writer(level), "", 0)
+}
+
+// StdLogger returns a standard library *log.Logger that logs each message
+// through the default (global) logger at level
+func StdLogger(level int) *log.Logger {
+	return defaultLogger.Load().StdLogger(level)
+}
+
+// Print logs its arguments, formatted as by fmt.Sprint, as an INFO message
+func (l *Logger) Print(v ...interface{}) {
+	if !l.maybeEnabled(INFO) {
+		return
+	}
+	l.Log(INFO, "%s", fmt.Sprint(v...))
+}
+
+// Printf logs an INFO message, it is equivalent to Info
+func (l *Logger) Printf(s string, args ...interface{}) {
+	l.Log(INFO, s, args...)
+}
+
+// Println logs its arguments, separated by spaces, as an INFO message
+func (l *Logger) Println(v ...interface{}) {
+	l.Logln(INFO, v...)
+}
@@ -0,0 +1,46 @@
+package simplelog
+
+import (
+	"sync"
+)
+
+type subscriber struct {
+	ch chan<- LogEntry
+}
+
+// Subscribe registers ch to receive a copy of every LogEntry emitted by
+// this Logger.  Sends are non-blocking, entries are dropped if ch is full.
+//
+// The returned function unsubscribes ch, after it returns no further
+// entries will be sent.
+func (l *Logger) Subscribe(ch chan<- LogEntry) func() {
+	sub := &subscriber{ch: ch}
+
+	l.Lock()
+	l.subscribers = append(l.subscribers, sub)
+	l.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.Lock()
+			defer l.Unlock()
+			for i, s := range l.subscribers {
+				if s == sub {
+					l.subscribers = append(l.subscribers[:i], l.subscribers[i+1:]...)
+					break
+				}
+			}
+		})
+	}
+}
+
+// publish must be called with the Logger's lock held
+func (l *Logger) publish(entry LogEntry) {
+	for _, s := range l.subscribers {
+		select {
+		case s.ch <- entry:
+		default:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+//go:build !windows && !plan9
+
+package simplelog
+
+import (
+	"log/syslog"
+)
+
+// SyslogHandler is a Handler that writes entries at or above its level to
+// the local syslog daemon, or a remote one over UDP/TCP, mapping simplelog
+// levels to syslog severities.
+type SyslogHandler struct {
+	w     *syslog.Writer
+	level int
+}
+
+// NewSyslogHandler connects to the syslog daemon at raddr over network
+// ("udp" or "tcp"), if network is empty the local daemon is used.  Messages
+// are tagged with tag (the program name if empty) and only entries at or
+// above level are written.
+func NewSyslogHandler(network, raddr string, tag string, level int) (*SyslogHandler, error) {
+	w, err := syslog.Dial(network, raddr, syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogHandler{w: w, level: level}, nil
+}
+
+// Handle implements Handler
+func (h *SyslogHandler) Handle(entry LogEntry) error {
+	if entry.Level < h.level {
+		return nil
+	}
+
+	msg := entry.Message + formatFields(entry.Fields)
+	switch {
+	case entry.Level <= DEBUG:
+		return h.w.Debug(msg)
+	case entry.Level == INFO:
+		return h.w.Info(msg)
+	case entry.Level == WARNING:
+		return h.w.Warning(msg)
+	case entry.Level == ERROR:
+		return h.w.Err(msg)
+	case entry.Level == FATAL:
+		return h.w.Crit(msg)
+	default:
+		return h.w.Emerg(msg)
+	}
+}
+
+// Close closes the connection to the syslog daemon
+func (h *SyslogHandler) Close() error {
+	return h.w.Close()
+}
@@ -0,0 +1,162 @@
+package simplelog
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotationInterval is how often a TimedRotatingFileWriter rolls its file
+type RotationInterval int
+
+const (
+	// RotateDaily rolls at midnight, ie. app-2024-05-01.log
+	RotateDaily RotationInterval = iota
+	// RotateHourly rolls at the top of every hour, ie. app-2024-05-01-15.log
+	RotateHourly
+)
+
+// TimedRotatingFileWriter is an io.WriteCloser that writes to a file named
+// with a date suffix and rolls to a new file at every interval boundary,
+// optionally gzipping the file it rolled from.
+type TimedRotatingFileWriter struct {
+	sync.Mutex
+	base     string
+	ext      string
+	interval RotationInterval
+	compress bool
+	f        *os.File
+	name     string
+	wg       sync.WaitGroup
+	gzipErr  error
+}
+
+// NewTimedRotatingFileWriter creates a TimedRotatingFileWriter whose files
+// are named after path with the period inserted before the extension, ie.
+// app.log is written as app-2024-05-01.log
+func NewTimedRotatingFileWriter(path string, interval RotationInterval, compress bool) (*TimedRotatingFileWriter, error) {
+	ext := filepath.Ext(path)
+	w := &TimedRotatingFileWriter{
+		base:     strings.TrimSuffix(path, ext),
+		ext:      ext,
+		interval: interval,
+		compress: compress,
+	}
+	if err := w.open(w.fileName(time.Now())); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes p to the file for the current period, rolling first if the
+// period has changed
+func (w *TimedRotatingFileWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.f == nil {
+		return 0, os.ErrClosed
+	}
+	if name := w.fileName(time.Now()); name != w.name {
+		// when the new file cannot be opened the current one is written
+		// to, rolling is retried on the next write
+		w.roll(name)
+	}
+	return w.f.Write(p)
+}
+
+// Close closes the current file and waits for any pending compression, it
+// returns the first error encountered compressing rolled files
+func (w *TimedRotatingFileWriter) Close() error {
+	w.Lock()
+	var err error
+	if w.f != nil {
+		err = w.f.Close()
+		w.f = nil
+	}
+	w.Unlock()
+
+	w.wg.Wait()
+
+	w.Lock()
+	if err == nil {
+		err = w.gzipErr
+	}
+	w.Unlock()
+	return err
+}
+
+func (w *TimedRotatingFileWriter) fileName(t time.Time) string {
+	layout := "2006-01-02"
+	if w.interval == RotateHourly {
+		layout = "2006-01-02-15"
+	}
+	return w.base + "-" + t.Format(layout) + w.ext
+}
+
+func (w *TimedRotatingFileWriter) open(name string) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	w.f = f
+	w.name = name
+	return nil
+}
+
+// roll switches to the file name, the current file is only closed (and
+// compressed) once name has been opened
+func (w *TimedRotatingFileWriter) roll(name string) error {
+	old, oldName := w.f, w.name
+	if err := w.open(name); err != nil {
+		return err
+	}
+	old.Close()
+
+	if w.compress {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			if err := gzipFile(oldName); err != nil {
+				w.Lock()
+				if w.gzipErr == nil {
+					w.gzipErr = err
+				}
+				w.Unlock()
+			}
+		}()
+	}
+	return nil
+}
+
+// gzipFile compresses path to path.gz and removes the original
+func gzipFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(out)
+	_, err = io.Copy(zw, in)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,27 @@
+package simplelog
+
+// SetTimeFormat sets the time.Format layout used for timestamps in the
+// default text format, ie. time.RFC3339.  An empty layout restores the
+// default "2006-01-02 15:04:05.000000".
+func (l *Logger) SetTimeFormat(layout string) {
+	l.Lock()
+	l.timeFormat = layout
+	l.Unlock()
+}
+
+// SetUTC toggles timestamping messages in UTC rather than local time
+func (l *Logger) SetUTC(enabled bool) {
+	l.Lock()
+	l.utc = enabled
+	l.Unlock()
+}
+
+// SetTimeFormat sets the timestamp layout of the default (global) logger
+func SetTimeFormat(layout string) {
+	defaultLogger.Load().SetTimeFormat(layout)
+}
+
+// SetUTC toggles UTC timestamps on the default (global) logger
+func SetUTC(enabled bool) {
+	defaultLogger.Load().SetUTC(enabled)
+}
@@ -0,0 +1,11 @@
+//go:build !darwin && !linux && !windows
+
+package simplelog
+
+import (
+	"os"
+)
+
+func isatty(f *os.File) bool {
+	return false
+}
@@ -0,0 +1,20 @@
+//go:build darwin || linux
+
+package simplelog
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func ioctl(fd, request, argp uintptr) syscall.Errno {
+	_, _, errorp := syscall.Syscall(syscall.SYS_IOCTL, fd, request, argp)
+	return errorp
+}
+
+func isatty(f *os.File) bool {
+	var t [2]byte
+	errno := ioctl(f.Fd(), syscall.TIOCGPGRP, uintptr(unsafe.Pointer(&t)))
+	return errno == 0
+}
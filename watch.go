@@ -0,0 +1,73 @@
+package simplelog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// WatchLevelURL polls url every interval and applies the level it returns
+// to l.  The endpoint must respond with a JSON body of the form:
+//
+//	{"level": "debug"}
+//
+// Network errors, non-200 responses (including 304 Not Modified) and
+// invalid levels leave the current level unchanged.  Each request times out
+// after interval.
+//
+// The returned function stops polling, aborting any request in flight.
+func WatchLevelURL(l *Logger, url string, interval time.Duration) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &http.Client{Timeout: interval}
+
+	go func() {
+		var etag, lastModified string
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			etag, lastModified = fetchLevel(ctx, client, l, url, etag, lastModified)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return cancel
+}
+
+func fetchLevel(ctx context.Context, client *http.Client, l *Logger, url string, etag string, lastModified string) (string, string) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return etag, lastModified
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return etag, lastModified
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return etag, lastModified
+	}
+
+	var body struct {
+		Level string `json:"level"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return etag, lastModified
+	}
+	if err := l.SetLevel(body.Level); err != nil {
+		return etag, lastModified
+	}
+	return resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+}
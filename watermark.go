@@ -0,0 +1,75 @@
+package simplelog
+
+import (
+	"sort"
+)
+
+type watermark struct {
+	mark  uint64
+	fired bool
+}
+
+// SetVolumeWatermarks arranges for a single INFO message to be logged when
+// the number of messages emitted at level reaches each of marks, ie:
+//
+//	log watermark: 1000000 DEBUG messages emitted
+//
+// Watermarks fire once, use ResetWatermarks to re-arm them.
+func (l *Logger) SetVolumeWatermarks(level int, marks ...uint64) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.watermarks == nil {
+		l.watermarks = make(map[int][]*watermark)
+	}
+	sorted := append([]uint64(nil), marks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	watermarks := make([]*watermark, len(sorted))
+	for i, mark := range sorted {
+		watermarks[i] = &watermark{mark: mark, fired: l.volume[level] >= mark}
+	}
+	l.watermarks[level] = watermarks
+}
+
+// ResetWatermarks restarts the message count for level and re-arms its
+// watermarks
+func (l *Logger) ResetWatermarks(level int) {
+	l.Lock()
+	defer l.Unlock()
+
+	delete(l.volume, level)
+	for _, w := range l.watermarks[level] {
+		w.fired = false
+	}
+}
+
+// logWatermark logs the INFO message for a watermark reached at level, it
+// must be called with the Logger's lock held
+func (l *Logger) logWatermark(level int, mark uint64) {
+	// the message itself does not count towards the INFO volume
+	watermarks := l.watermarks
+	l.watermarks = nil
+	_, levelTxt := parseLevel(level)
+	l.log(INFO, "log watermark: %d %s messages emitted", []interface{}{mark, levelTxt})
+	l.watermarks = watermarks
+}
+
+// countVolume must be called with the Logger's lock held, it returns the
+// watermark reached (if any)
+func (l *Logger) countVolume(level int) uint64 {
+	if len(l.watermarks[level]) == 0 {
+		return 0
+	}
+	if l.volume == nil {
+		l.volume = make(map[int]uint64)
+	}
+	l.volume[level]++
+	count := l.volume[level]
+	for _, w := range l.watermarks[level] {
+		if !w.fired && count >= w.mark {
+			w.fired = true
+			return w.mark
+		}
+	}
+	return 0
+}
@@ -0,0 +1,48 @@
+package simplelog
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+type levelWriter struct {
+	sync.Mutex
+	l     *Logger
+	level int
+	buf   []byte
+}
+
+// Writer returns an io.Writer that logs each line written to it at level,
+// ie. to route the standard library's log package through simplelog:
+//
+//	log.SetFlags(0)
+//	log.SetOutput(logger.Writer(simplelog.INFO))
+func (l *Logger) Writer(level int) io.Writer {
+	return &levelWriter{l: l, level: level}
+}
+
+// Writer returns an io.Writer that logs each line written to it at level on
+// the default (global) logger
+func Writer(level int) io.Writer {
+	return defaultLogger.Load().Writer(level)
+}
+
+// Write logs every complete line in p, partial lines are buffered until
+// their newline is written
+func (w *levelWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx == -1 {
+			break
+		}
+		line := bytes.TrimSuffix(w.buf[:idx], []byte("\r"))
+		w.l.Log(w.level, "%s", line)
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}